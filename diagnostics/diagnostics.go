@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diagnostics collects the problems found by the generators while
+// generating a reference, so that a run can tell whether it was clean and
+// report the problems in a machine-readable form.
+//
+// The generators report a problem with a code identifying its kind, e.g.
+// "missing-link-end", a severity and the location of the problem in the
+// API. Errors fail the run, warnings only fail it in strict mode.
+package diagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Severity is the severity of a diagnostic.
+type Severity string
+
+const (
+	// Warning is a problem which leaves the output usable, e.g. a link
+	// which cannot be resolved and is written as plain text.
+	Warning Severity = "warning"
+	// Error is a problem which makes the output incomplete or wrong.
+	Error Severity = "error"
+)
+
+// Location locates a diagnostic in the API being documented. All the fields
+// are optional.
+type Location struct {
+	// Definition is the key of the definition or Go type, e.g.
+	// io.k8s.api.core.v1.Pod.
+	Definition string `json:"definition,omitempty"`
+	// Field is the path of the field in the definition, e.g.
+	// spec.containers.
+	Field string `json:"field,omitempty"`
+	// Operation is the ID of the operation, e.g. readCoreV1NamespacedPod.
+	Operation string `json:"operation,omitempty"`
+}
+
+// String returns the location as "definition field operation", omitting
+// the empty fields.
+func (l Location) String() string {
+	var parts []string
+	if l.Definition != "" {
+		parts = append(parts, l.Definition)
+	}
+	if l.Field != "" {
+		parts = append(parts, "field "+l.Field)
+	}
+	if l.Operation != "" {
+		parts = append(parts, "operation "+l.Operation)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Diagnostic is a problem found while generating a reference.
+type Diagnostic struct {
+	// Code identifies the kind of the problem, e.g. "missing-link-end".
+	Code     string   `json:"code"`
+	Severity Severity `json:"severity"`
+	Location Location `json:"location"`
+	Message  string   `json:"message"`
+}
+
+// String returns the diagnostic as a line of log, e.g.
+// "warning [orphaned-definition] io.k8s.api.core.v1.Pod: not referenced".
+func (d Diagnostic) String() string {
+	s := fmt.Sprintf("%s [%s]", d.Severity, d.Code)
+	if loc := d.Location.String(); loc != "" {
+		s += " " + loc
+	}
+	return s + ": " + d.Message
+}
+
+// Report is the JSON document written by WriteReport.
+type Report struct {
+	// Clean is true when no warning and no error were reported.
+	Clean       bool         `json:"clean"`
+	Errors      int          `json:"errors"`
+	Warnings    int          `json:"warnings"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// Collector collects the diagnostics of a run. It is safe for concurrent
+// use.
+type Collector struct {
+	mu          sync.Mutex
+	out         io.Writer
+	diagnostics []Diagnostic
+}
+
+// New returns a collector logging each diagnostic to out when reported.
+// out may be nil to only collect the diagnostics.
+func New(out io.Writer) *Collector {
+	return &Collector{out: out}
+}
+
+// Warningf reports a warning.
+func (c *Collector) Warningf(code string, loc Location, format string, args ...any) {
+	c.add(Warning, code, loc, format, args...)
+}
+
+// Errorf reports an error.
+func (c *Collector) Errorf(code string, loc Location, format string, args ...any) {
+	c.add(Error, code, loc, format, args...)
+}
+
+func (c *Collector) add(severity Severity, code string, loc Location, format string, args ...any) {
+	d := Diagnostic{
+		Code:     code,
+		Severity: severity,
+		Location: loc,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.diagnostics = append(c.diagnostics, d)
+	if c.out != nil {
+		fmt.Fprintln(c.out, d)
+	}
+}
+
+// Report returns the diagnostics reported so far, errors first, then
+// sorted by code and location so that the report does not depend on the
+// order in which the generator visited the API.
+func (c *Collector) Report() Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r := Report{Diagnostics: append([]Diagnostic{}, c.diagnostics...)}
+	for _, d := range r.Diagnostics {
+		if d.Severity == Error {
+			r.Errors++
+		} else {
+			r.Warnings++
+		}
+	}
+	r.Clean = r.Errors == 0 && r.Warnings == 0
+	sort.SliceStable(r.Diagnostics, func(i, j int) bool {
+		a, b := r.Diagnostics[i], r.Diagnostics[j]
+		if a.Severity != b.Severity {
+			return a.Severity == Error
+		}
+		if a.Code != b.Code {
+			return a.Code < b.Code
+		}
+		if a.Location != b.Location {
+			return a.Location.String() < b.Location.String()
+		}
+		return a.Message < b.Message
+	})
+	return r
+}
+
+// WriteReport writes the report of the diagnostics as JSON to the file,
+// or to the standard output if file is "-".
+func (c *Collector) WriteReport(file string) error {
+	data, err := json.MarshalIndent(c.Report(), "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if file == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(file, data, 0644)
+}
+
+// Err returns an error if errors were reported or, in strict mode, if
+// warnings were reported. It returns nil for a clean run.
+func (c *Collector) Err(strict bool) error {
+	r := c.Report()
+	if r.Errors > 0 || (strict && r.Warnings > 0) {
+		return fmt.Errorf("generation reported %d errors and %d warnings", r.Errors, r.Warnings)
+	}
+	return nil
+}
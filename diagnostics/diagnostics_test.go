@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCollector(t *testing.T) {
+	var log bytes.Buffer
+	c := New(&log)
+	if err := c.Err(true); err != nil {
+		t.Errorf("Err of an empty collector = %v, want nil", err)
+	}
+
+	c.Warningf("orphaned-definition", Location{Definition: "io.k8s.api.core.v1.Pod"}, "not referenced")
+	c.Errorf("missing-link-end", Location{Definition: "io.k8s.api.core.v1.Status", Operation: "readCoreV1NamespacedPod"}, "no link end for response %d", 200)
+	c.Warningf("guessed-group-name", Location{}, "full name for %q not provided", "apps")
+
+	wantLog := `warning [orphaned-definition] io.k8s.api.core.v1.Pod: not referenced
+error [missing-link-end] io.k8s.api.core.v1.Status operation readCoreV1NamespacedPod: no link end for response 200
+warning [guessed-group-name]: full name for "apps" not provided
+`
+	if log.String() != wantLog {
+		t.Errorf("got log\n%s\nwant\n%s", log.String(), wantLog)
+	}
+
+	r := c.Report()
+	if r.Clean || r.Errors != 1 || r.Warnings != 2 {
+		t.Errorf("got clean=%v errors=%d warnings=%d, want false 1 2", r.Clean, r.Errors, r.Warnings)
+	}
+	var codes []string
+	for _, d := range r.Diagnostics {
+		codes = append(codes, d.Code)
+	}
+	if want := []string{"missing-link-end", "guessed-group-name", "orphaned-definition"}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("got diagnostics %v, want %v", codes, want)
+	}
+
+	if err := c.Err(false); err == nil {
+		t.Error("Err should fail on errors")
+	}
+}
+
+func TestErrStrict(t *testing.T) {
+	c := New(nil)
+	c.Warningf("orphaned-definition", Location{Definition: "io.k8s.api.core.v1.Pod"}, "not referenced")
+	if err := c.Err(false); err != nil {
+		t.Errorf("Err should not fail on warnings: %v", err)
+	}
+	if err := c.Err(true); err == nil {
+		t.Error("Err should fail on warnings in strict mode")
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	c := New(nil)
+	c.Warningf("deep-indentation", Location{Definition: "io.k8s.api.core.v1.Pod", Field: "spec.containers.ports"}, "indentation is %d", 4)
+	file := filepath.Join(t.TempDir(), "diagnostics.json")
+	if err := c.WriteReport(file); err != nil {
+		t.Fatalf("WriteReport failed: %v", err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid report: %v", err)
+	}
+	want := map[string]any{
+		"clean":    false,
+		"errors":   0.0,
+		"warnings": 1.0,
+		"diagnostics": []any{
+			map[string]any{
+				"code":     "deep-indentation",
+				"severity": "warning",
+				"location": map[string]any{
+					"definition": "io.k8s.api.core.v1.Pod",
+					"field":      "spec.containers.ports",
+				},
+				"message": "indentation is 4",
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got report %v, want %v", got, want)
+	}
+}
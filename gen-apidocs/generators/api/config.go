@@ -23,6 +23,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
 	"unicode"
@@ -31,14 +32,26 @@ import (
 
 	"github.com/go-openapi/loads"
 	"github.com/go-openapi/spec"
+
+	"github.com/kubernetes-sigs/reference-docs/diagnostics"
 )
 
 var AllowErrors = flag.Bool("allow-errors", false, "If true, don't fail on errors.")
 var WorkDir = flag.String("work-dir", "", "Working directory for the generator.")
 var UseTags = flag.Bool("use-tags", false, "If true, use the openapi tags instead of the config yaml.")
 var KubernetesRelease = flag.String("kubernetes-release", "", "Kubernetes release version.")
-var Backend = flag.String("backend", "html", "Output format for the generator. Supported values: 'html', 'markdown', or 'hugo-md'.")
+var Backend = flag.String("backend", "html", "Output format for the generator. Supported values: 'html', 'html-multipage', 'asciidoc', 'markdown', or 'hugo-md'.")
 var AutoDetect = flag.Bool("auto-detect", false, "If true, auto-detect API groups and versions from swagger.json.")
+var TemplatesDir = flag.String("templates-dir", "", "Directory of templates overriding the embedded ones of the 'html' and 'html-multipage' backends.")
+var DocBook = flag.Bool("docbook", false, "If true, the 'asciidoc' backend also exports the reference as DocBook with asciidoctor.")
+var SiteURL = flag.String("site-url", "", "Base URL of the 'html-multipage' output, used in sitemap.xml. Defaults to the reference of the release on kubernetes.io.")
+var Strict = flag.Bool("strict", false, "If true, fail on warnings as well as errors, even with --allow-errors.")
+var DiagnosticsFile = flag.String("diagnostics-file", "", "File to write the warnings and errors of the run to as JSON, '-' for the standard output.")
+var Workers = flag.Int("workers", runtime.NumCPU(), "Number of pages rendered concurrently. The output does not depend on it.")
+
+// Diagnostics collects the warnings and errors found while generating the
+// reference. GenerateFiles replaces it on each run.
+var Diagnostics = diagnostics.New(os.Stderr)
 
 // titleCase converts a string to title case as a replacement for deprecated strings.Title
 func titleCase(s string) string {
@@ -83,6 +96,10 @@ func NewConfig() (*Config, error) {
 		BuildDir = filepath.Join(buildRoot, "markdown")
 	case "hugo-md":
 		BuildDir = filepath.Join(buildRoot, "hugo-md")
+	case "html-multipage":
+		BuildDir = filepath.Join(buildRoot, "html-multipage")
+	case "asciidoc":
+		BuildDir = filepath.Join(buildRoot, "asciidoc")
 	default:
 		BuildDir = filepath.Join(buildRoot, "html")
 	}
@@ -211,10 +228,14 @@ func processDefinitionsAndOperations(config *Config) error {
 func (c *Config) mergeAnnotations(originalCategories []ResourceCategory) {
 	// Build a lookup: "group/version/name" -> annotation fields
 	annotations := map[string]*Resource{}
+	var keys []string
 	for _, cat := range originalCategories {
 		for _, r := range cat.Resources {
 			if r.DescriptionWarning != "" || r.DescriptionNote != "" || r.ConceptGuide != "" {
 				key := r.Group + "/" + r.Version + "/" + r.Name
+				if _, found := annotations[key]; !found {
+					keys = append(keys, key)
+				}
 				annotations[key] = r
 			}
 		}
@@ -234,11 +255,12 @@ func (c *Config) mergeAnnotations(originalCategories []ResourceCategory) {
 		}
 	}
 
-	// Add annotated resources that weren't placed
-	for key, r := range annotations {
+	// Add annotated resources that weren't placed, in the order of the config
+	for _, key := range keys {
 		if placed[key] {
 			continue
 		}
+		r := annotations[key]
 		// Find or create the group category
 		found := false
 		for i, cat := range c.ResourceCategories {
@@ -274,7 +296,7 @@ func (c *Config) mergeAnnotations(originalCategories []ResourceCategory) {
 func (c *Config) buildGroupBasedCategories() {
 	// build the apis from the observed groups
 	groupsMap := map[ApiGroup]DefinitionList{}
-	for _, d := range c.Definitions.All {
+	for _, d := range c.Definitions.Sorted() {
 		// Old version (v1alpha1 when v1beta1 exists)
 		if d.IsOldVersion {
 			continue
@@ -299,16 +321,9 @@ func (c *Config) buildGroupBasedCategories() {
 		g := d.Group
 		groupsMap[g] = append(groupsMap[g], d)
 	}
-	// Sort groups alphabetically
-	groupsList := ApiGroups{}
-	for g := range groupsMap {
-		groupsList = append(groupsList, g)
-	}
-	sort.Sort(groupsList)
-
 	// Build one category per group
 	categories := []ResourceCategory{}
-	for _, g := range groupsList {
+	for _, g := range sortedGroups(groupsMap) {
 		groupName := titleCase(string(g))
 		rc := ResourceCategory{
 			Include: string(g),
@@ -333,6 +348,17 @@ func (c *Config) buildGroupBasedCategories() {
 	c.ResourceCategories = categories
 }
 
+// sortedGroups returns the groups of the categories built from groupsMap,
+// sorted alphabetically like the titles of the categories.
+func sortedGroups(groupsMap map[ApiGroup]DefinitionList) ApiGroups {
+	groups := make(ApiGroups, 0, len(groupsMap))
+	for g := range groupsMap {
+		groups = append(groups, g)
+	}
+	sort.Sort(groups)
+	return groups
+}
+
 // pruneResourceCategories removes resources that shouldn't be in the ToC
 func (c *Config) pruneResourceCategories() {
 	categories := []ResourceCategory{}
@@ -358,7 +384,7 @@ func (c *Config) genConfigFromTags(specs []*loads.Document) error {
 
 	// build the apis from the observed groups
 	groupsMap := map[ApiGroup]DefinitionList{}
-	for _, d := range c.Definitions.All {
+	for _, d := range c.Definitions.Sorted() {
 		if strings.HasSuffix(d.Name, "List") {
 			continue
 		}
@@ -376,14 +402,7 @@ func (c *Config) genConfigFromTags(specs []*loads.Document) error {
 		groupsMap[g] = append(groupsMap[g], d)
 	}
 
-	groupsList := ApiGroups{}
-	for g := range groupsMap {
-		groupsList = append(groupsList, g)
-	}
-
-	sort.Sort(groupsList)
-
-	for _, g := range groupsList {
+	for _, g := range sortedGroups(groupsMap) {
 		groupName := titleCase(string(g))
 		c.ApiGroups = append(c.ApiGroups, ApiGroup(groupName))
 		rc := ResourceCategory{
@@ -411,7 +430,7 @@ func (config *Config) initOperationsFromTags(specs []*loads.Document) error {
 	if *UseTags {
 		ops := map[string]map[string][]*Operation{}
 		defs := map[string]*Definition{}
-		for _, d := range config.Definitions.All {
+		for _, d := range config.Definitions.Sorted() {
 			name := fmt.Sprintf("%s.%s.%s", d.Group, d.Version, d.GetResourceName())
 			defs[name] = d
 		}
@@ -440,7 +459,13 @@ func (config *Config) initOperationsFromTags(specs []*loads.Document) error {
 			ops[key][sub] = append(ops[key][sub], o)
 		})
 
-		for key, subMap := range ops {
+		keys := make([]string, 0, len(ops))
+		for key := range ops {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			subMap := ops[key]
 			def := defs[key]
 			if def == nil {
 				return fmt.Errorf("unable to locate resource %s in resource map: %v", key, defs)
@@ -485,7 +510,7 @@ func (c *Config) initOperations(specs []*loads.Document) error {
 	VisitOperations(specs, func(target Operation) {
 		if op, ok := c.Operations[target.ID]; !ok || op.Definition == nil {
 			if !c.OpExcluded(op.ID) {
-				log.Printf("No definition found for operation %s [%s]", op.ID, op.Path)
+				Diagnostics.Warningf("operation-without-definition", diagnostics.Location{Operation: op.ID}, "no definition found for operation %s", op.Path)
 			} else {
 				log.Printf("Operation excluded: %s", op.ID)
 			}
@@ -740,7 +765,13 @@ func (c *Config) initOperationParameters(specs []*loads.Document) error {
 			}
 		}
 
-		for code, response := range op.op.Responses.StatusCodeResponses {
+		codes := make([]int, 0, len(op.op.Responses.StatusCodeResponses))
+		for code := range op.op.Responses.StatusCodeResponses {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		for _, code := range codes {
+			response := op.op.Responses.StatusCodeResponses[code]
 			if response.Schema == nil {
 				continue
 			}
@@ -811,7 +842,7 @@ func (c *Config) setOperation(match, namespace string, ot *OperationType, oc *Op
 
 // mapOperationsToDefinitions adds operations to the definitions they operate
 func (c *Config) mapOperationsToDefinitions() error {
-	for _, d := range c.Definitions.All {
+	for _, d := range c.Definitions.Sorted() {
 		if d.IsInlined {
 			continue
 		}
@@ -902,7 +933,7 @@ func (c *Config) visitResourcesInToc() error {
 			if AutoDetect != nil && *AutoDetect {
 				newest := c.Definitions.FindNewestVersion(r.Group, r.Name)
 				if newest == "" {
-					fmt.Printf("Resource %s/%s not found in swagger, removing from ToC\n", r.Group, r.Name)
+					Diagnostics.Warningf("resource-not-found", diagnostics.Location{Definition: fmt.Sprintf("%s.%s", r.Group, r.Name)}, "resource not found in swagger, removing from ToC")
 					missing = true
 					continue
 				}
@@ -919,7 +950,7 @@ func (c *Config) visitResourcesInToc() error {
 				}
 				r.Definition = d
 			} else {
-				fmt.Printf("\033[31mCould not find definition for resource in TOC: %s %s %s.\033[0m\n", r.Group, r.Version, r.Name)
+				Diagnostics.Errorf("missing-definition", diagnostics.Location{Definition: fmt.Sprintf("%s.%s.%s", r.Group, r.Version, r.Name)}, "could not find definition for resource in TOC")
 				missing = true
 			}
 
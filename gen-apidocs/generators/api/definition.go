@@ -27,6 +27,8 @@ import (
 
 	"github.com/go-openapi/loads"
 	"github.com/go-openapi/spec"
+
+	"github.com/kubernetes-sigs/reference-docs/diagnostics"
 )
 
 // inlineDefinition is a definition that should be inlined when displaying a Concept
@@ -61,13 +63,25 @@ func NewDefinitions(config *Config, specs []*loads.Document) (*Definitions, erro
 	return s, nil
 }
 
+// Sorted returns all the definitions sorted by name, version and group, to
+// iterate over them in the same order on every run.
+func (s *Definitions) Sorted() SortDefinitionsByName {
+	defs := make(SortDefinitionsByName, 0, len(s.All))
+	for _, d := range s.All {
+		defs = append(defs, d)
+	}
+	sort.Sort(defs)
+	return defs
+}
+
 func (s *Definitions) initialize() {
+	all := s.Sorted()
 	// initialize fields for all definitions
-	for _, d := range s.All {
+	for _, d := range all {
 		s.InitializeFields(d)
 	}
 
-	for _, d := range s.All {
+	for _, d := range all {
 		key := byKindKey{Group: string(d.Group), Kind: d.Name}
 		s.ByKind[key] = append(s.ByKind[key], d)
 	}
@@ -95,7 +109,7 @@ func (s *Definitions) initialize() {
 	}
 
 	// Initialize OtherVersions (same group + same name, different version)
-	for _, d := range s.All {
+	for _, d := range all {
 		defs := s.ByKind[byKindKey{Group: string(d.Group), Kind: d.Name}]
 		others := []*Definition{}
 		for _, def := range defs {
@@ -107,7 +121,7 @@ func (s *Definitions) initialize() {
 	}
 
 	// Initialize AppearsIn and FoundInField
-	for _, d := range s.All {
+	for _, d := range all {
 		for _, r := range s.getReferences(d) {
 			r.AppearsIn = append(r.AppearsIn, d)
 			r.FoundInField = true
@@ -116,7 +130,7 @@ func (s *Definitions) initialize() {
 
 	// Initialize Inline, IsInlined
 	// Note: examples of inline definitions are "Spec", "Status", "List", etc
-	for _, d := range s.All {
+	for _, d := range all {
 		for _, name := range s.getInlineDefinitionNames(d.Name) {
 			if cr, ok := s.GetByVersionKind(string(d.Group), string(d.Version), name); ok {
 				d.Inline = append(d.Inline, cr)
@@ -139,7 +153,7 @@ func (s *Definitions) getInlineDefinitionNames(parent string) []string {
 func (s *Definitions) getReferences(d *Definition) []*Definition {
 	refs := []*Definition{}
 	// Find all of the definitions referenced by this definition
-	for _, p := range d.schema.Properties {
+	for name, p := range d.schema.Properties {
 		if p.AdditionalProperties != nil && p.AdditionalProperties.Schema != nil {
 			additionalProperty := p.AdditionalProperties.Schema.Ref
 			if len(additionalProperty.String()) > 0 {
@@ -159,7 +173,7 @@ func (s *Definitions) getReferences(d *Definition) []*Definition {
 			refs = append(refs, schema)
 		} else {
 			g, v, k := GetDefinitionVersionKind(p)
-			fmt.Printf("Could not locate referenced property of %s: %s (%s/%s).\n", d.Name, g, k, v)
+			Diagnostics.Warningf("unresolved-reference", diagnostics.Location{Definition: d.Key(), Field: name}, "could not locate referenced definition %s (%s/%s)", g, k, v)
 		}
 	}
 
@@ -470,5 +484,5 @@ func (d *Definition) GetSamples() []ExampleText {
 func (a DefinitionList) Len() int      { return len(a) }
 func (a DefinitionList) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 func (a DefinitionList) Less(i, j int) bool {
-	return strings.Compare(a[i].Name, a[j].Name) < 0
+	return SortDefinitionsByName(a).Less(i, j)
 }
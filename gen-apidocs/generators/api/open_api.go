@@ -24,6 +24,9 @@ import (
 	"strings"
 
 	"github.com/go-openapi/loads"
+	"github.com/go-openapi/spec"
+
+	"github.com/kubernetes-sigs/reference-docs/diagnostics"
 )
 
 const (
@@ -63,7 +66,9 @@ func LoadDefinitions(config *Config, specs []*loads.Document, s *Definitions) er
 	var versionList ApiVersions
 
 	for _, spec := range specs {
-		for name, spec := range spec.Spec().Definitions {
+		definitions := spec.Spec().Definitions
+		for _, name := range sortedDefinitionNames(definitions) {
+			spec := definitions[name]
 			resource := ""
 			if r, ok := spec.Extensions.GetString(resourceNameKey); ok {
 				resource = r
@@ -91,7 +96,7 @@ func LoadDefinitions(config *Config, specs []*loads.Document, s *Definitions) er
 			full_group, found := config.GroupFullNames[group]
 			if !found {
 				// fall back to group name if no mapping found
-				fmt.Printf("\033[31mWarning: full name for '%s' not provided, guessing...\033[0m\n", group)
+				Diagnostics.Warningf("guessed-group-name", diagnostics.Location{Definition: name}, "full name for '%s' not provided, guessing...", group)
 				full_group = group
 			}
 
@@ -142,7 +147,9 @@ func DetectGroupsFromSpec(specs []*loads.Document) (map[string]string, []string)
 	apiGroups := []string{}
 
 	for _, spec := range specs {
-		for name, def := range spec.Spec().Definitions {
+		definitions := spec.Spec().Definitions
+		for _, name := range sortedDefinitionNames(definitions) {
+			def := definitions[name]
 			group, _, _ := GuessGVK(name)
 			if group == "" {
 				continue
@@ -183,6 +190,17 @@ func DetectGroupsFromSpec(specs []*loads.Document) (map[string]string, []string)
 	return groupFullNames, apiGroups
 }
 
+// sortedDefinitionNames returns the names of the definitions of a spec, sorted
+// so that the definitions are loaded in the same order on every run.
+func sortedDefinitionNames(definitions spec.Definitions) []string {
+	names := make([]string, 0, len(definitions))
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func ParseSpecInfo(specs []*loads.Document, cfg *Config) {
 	// The following loop can be optimized, there is now only one spec for analysis
 	for _, spec := range specs {
@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v2"
@@ -95,12 +96,20 @@ func (a HttpResponses) Len() int           { return len(a) }
 func (a HttpResponses) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a HttpResponses) Less(i, j int) bool { return a[i].Code < a[j].Code }
 
-// VisitOperations calls fn once for each operation found in the collection of Documents
-// VisitOperations calls fn once for each operation found in the collection of Documents
+// VisitOperations calls fn once for each operation found in the collection of Documents,
+// ordered by path and method
 func VisitOperations(specs []*loads.Document, fn func(operation Operation)) {
 	for _, d := range specs {
-		for path, item := range d.Spec().Paths.Paths {
-			for method, operation := range getOperationsForItem(item) {
+		paths := make([]string, 0, len(d.Spec().Paths.Paths))
+		for path := range d.Spec().Paths.Paths {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			item := d.Spec().Paths.Paths[path]
+			operations := getOperationsForItem(item)
+			for _, method := range httpMethods {
+				operation := operations[method]
 				if operation != nil && !IsBlacklistedOperation(operation) {
 					fn(Operation{
 						item:       item,
@@ -124,6 +133,10 @@ func IsBlacklistedOperation(o *spec.Operation) bool {
 	//strings.HasPrefix(o.ID, "proxy")
 }
 
+// httpMethods are the methods of the operations returned by getOperationsForItem,
+// in the order they are visited
+var httpMethods = []string{"GET", "DELETE", "PATCH", "PUT", "POST", "HEAD"}
+
 // Get all operations from the pathitem so we cacn iterate over them
 func getOperationsForItem(pathItem spec.PathItem) map[string]*spec.Operation {
 	return map[string]*spec.Operation{
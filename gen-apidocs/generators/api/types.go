@@ -30,48 +30,32 @@ func (g ApiGroup) String() string {
 	return string(g)
 }
 
+// LessThan orders the groups alphabetically, except that the groups whose
+// APIs moved to newer groups sort right after them: "extensions" after
+// "apps", "networking" and "policy", and "core" after "events". The order is
+// total, as required by sort.Sort.
 func (g ApiGroup) LessThan(other ApiGroup) bool {
-	// "apps" group APIs are newer than "extensions" group APIs
-	if g.String() == "apps" && other.String() == "extensions" {
-		return true
-	}
-	if other.String() == "apps" && g.String() == "extensions" {
-		return false
-	}
-
-	// "policy" group APIs are newer than "extensions" group APIs
-	if g == "policy" && other.String() == "extensions" {
-		return true
-	}
-	if other.String() == "policy" && g.String() == "extensions" {
-		return false
-	}
-
-	// "networking" group APIs are newer than "extensions" group APIs
-	if g.String() == "networking" && other.String() == "extensions" {
-		return true
-	}
-	if other.String() == "networking" && g.String() == "extensions" {
-		return false
-	}
+	return g.sortKey() < other.sortKey()
+}
 
-	// "events" group APIs are newer than "core" group APIs
-	if g.String() == "events" && other.String() == "core" {
-		return true
-	}
-	if other.String() == "events" && g.String() == "core" {
-		return false
+func (g ApiGroup) sortKey() string {
+	switch g {
+	case "extensions":
+		return "policy~"
+	case "core":
+		return "events~"
 	}
-
-	return strings.Compare(g.String(), other.String()) < 0
+	return string(g)
 }
 
+// ApiGroups sorts the groups alphabetically, the order of the groups listed
+// in the documentation.
 type ApiGroups []ApiGroup
 
 func (a ApiGroups) Len() int      { return len(a) }
 func (a ApiGroups) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 func (a ApiGroups) Less(i, j int) bool {
-	return a[i].LessThan(a[j])
+	return a[i] < a[j]
 }
 
 type ApiKind string
@@ -21,6 +21,8 @@ import (
 	"strings"
 
 	"github.com/go-openapi/spec"
+
+	"github.com/kubernetes-sigs/reference-docs/diagnostics"
 )
 
 // GetDefinitionVersionKind returns the api version and kind for the spec.  This is the primary key of a Definition.
@@ -119,7 +121,7 @@ func IsComplex(schema spec.Schema) bool {
 func GuessGVK(name string) (group, version, kind string) {
 	parts := strings.Split(name, ".")
 	if len(parts) < 4 {
-		fmt.Printf("Error: Could not find version and type for definition %s.\n", name)
+		Diagnostics.Warningf("unknown-definition-version", diagnostics.Location{Definition: name}, "could not find version and type for definition")
 		return "", "", ""
 	}
 
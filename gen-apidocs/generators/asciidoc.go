@@ -0,0 +1,436 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+// AsciiDocWriter writes the reference as an AsciiDoc book, to be built into
+// PDF or ePub with the asciidoctor toolchain:
+//
+//	index.adoc             — the book, including the files below in order
+//	includes/_*.adoc       — the sections, resources, definitions and operations
+//	index.xml              — the DocBook export, with --docbook
+//
+// Resources and definitions are anchored by Definition.LinkID, which is the
+// anchor of the links returned by Field.FullLink, so that the types of the
+// fields are cross-references.
+type AsciiDocWriter struct {
+	Config    *api.Config
+	Copyright string
+	Title     string
+
+	// includes are the files of the book, in order.
+	includes []string
+}
+
+var _ DocWriter = (*AsciiDocWriter)(nil)
+
+//go:embed templates/asciidoc.tmpl
+var asciidocTemplateSrc string
+
+// cell escapes the separators in the text of a table cell.
+var asciidocTemplate = template.Must(template.New("asciidoc").Funcs(template.FuncMap{
+	"cell": func(s string) string {
+		return strings.ReplaceAll(s, "|", `\|`)
+	},
+	"join": strings.Join,
+}).Parse(asciidocTemplateSrc))
+
+type adocSection struct {
+	ID    string
+	Title string
+}
+
+type adocField struct {
+	Name          string
+	Type          string
+	PatchStrategy string
+	PatchMergeKey string
+	Description   string
+}
+
+type adocSample struct {
+	Title string
+	Lang  string
+	Text  string
+}
+
+type adocParams struct {
+	Title  string
+	Fields []adocField
+}
+
+type adocOperation struct {
+	ID string
+	// Level is the heading marker of the operation, e.g. "====".
+	Level           string
+	Title           string
+	RequestSamples  []adocSample
+	ResponseSamples []adocSample
+	Description     string
+	HTTP            string
+	Params          []adocParams
+	Responses       []adocField
+}
+
+type adocOperationCategory struct {
+	ID         string
+	Name       string
+	Operations []adocOperation
+}
+
+type adocInlineDefinition struct {
+	ID        string
+	Title     string
+	AppearsIn []string
+	Fields    []adocField
+}
+
+type adocDefinition struct {
+	ID            string
+	Title         string
+	Group         string
+	Version       api.ApiVersion
+	Kind          string
+	Samples       []adocSample
+	Description   string
+	Warning       string
+	Note          string
+	OtherVersions []string
+	AppearsIn     []string
+	Fields        []adocField
+	Inline        []adocInlineDefinition
+	Categories    []adocOperationCategory
+}
+
+type adocIndex struct {
+	Title     string
+	Version   string
+	Copyright string
+	Includes  []string
+}
+
+func NewAsciiDocWriter(config *api.Config, copyright, title string) DocWriter {
+	return &AsciiDocWriter{
+		Config:    config,
+		Copyright: plainTitle(copyright),
+		Title:     title,
+	}
+}
+
+func (a *AsciiDocWriter) Extension() string {
+	return ".adoc"
+}
+
+func (a *AsciiDocWriter) DefaultStaticContent(title string) string {
+	return "== " + title + "\n"
+}
+
+func (a *AsciiDocWriter) render(name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := asciidocTemplate.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("asciidoc: %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// writeFile renders a template into a file of the includes directory.
+func (a *AsciiDocWriter) writeFile(fn, name string, data any) error {
+	content, err := a.render(name, data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(api.IncludesDir, fn), []byte(content), 0644)
+}
+
+// writeInclude renders a template into a file of the includes directory and
+// adds the file to the book.
+func (a *AsciiDocWriter) writeInclude(fn, name string, data any) error {
+	if err := a.writeFile(fn, name, data); err != nil {
+		return err
+	}
+	a.includes = append(a.includes, fn)
+	return nil
+}
+
+// include adds a page to the book and returns the function rendering it,
+// data being called when rendering.
+func (a *AsciiDocWriter) include(fn, name string, data func() any) RenderFunc {
+	a.includes = append(a.includes, fn)
+	return func() error {
+		return a.writeFile(fn, name, data())
+	}
+}
+
+// writeSection writes the heading of a section, unless the sections
+// directory has a file with its content.
+func (a *AsciiDocWriter) writeSection(fn, id, title string) error {
+	content, err := a.render("section", adocSection{ID: id, Title: title})
+	if err != nil {
+		return err
+	}
+	if err := writeStaticFile(fn, content); err != nil {
+		return err
+	}
+	a.includes = append(a.includes, fn)
+	return nil
+}
+
+func (a *AsciiDocWriter) WriteOverview() error {
+	return a.writeSection("_overview.adoc", "api-overview", "API Overview")
+}
+
+func (a *AsciiDocWriter) WriteAPIGroupVersions(gvs api.GroupVersions) error {
+	return a.writeInclude("_group_versions.adoc", "group-versions", sortedGroupVersions(gvs))
+}
+
+func (a *AsciiDocWriter) WriteResourceCategory(name, file string) error {
+	id := strings.ToLower(strings.ReplaceAll(name, " ", "-"))
+	return a.writeSection("_"+file+".adoc", id, name)
+}
+
+func (a *AsciiDocWriter) WriteDefinitionsOverview() error {
+	return a.writeSection("_definitions.adoc", "definitions", "Definitions")
+}
+
+func (a *AsciiDocWriter) WriteOrphanedOperationsOverview() error {
+	return a.writeSection("_operations.adoc", "operations", "Operations")
+}
+
+func (a *AsciiDocWriter) WriteOldVersionsOverview() error {
+	return a.writeSection("_oldversions.adoc", "old-api-versions", "Old API Versions")
+}
+
+var hrefLink = regexp.MustCompile(`<a href="#([^"]+)">([^<]*)</a>`)
+
+// xref turns the HTML links of Field.FullLink, Definition.FullHrefLink and
+// Definition.VersionLink into cross-references.
+func xref(link string) string {
+	return hrefLink.ReplaceAllString(link, "<<$1,$2>>")
+}
+
+func (a *AsciiDocWriter) fields(fields api.Fields) []adocField {
+	var result []adocField
+	for _, f := range fields {
+		field := adocField{
+			Name:          f.Name,
+			PatchStrategy: f.PatchStrategy,
+			PatchMergeKey: f.PatchMergeKey,
+			Description:   f.Description,
+		}
+		if f.Link() != "" {
+			field.Type = xref(f.FullLink())
+		}
+		result = append(result, field)
+	}
+	return result
+}
+
+func (a *AsciiDocWriter) appearsIn(d *api.Definition) []string {
+	var result []string
+	for _, in := range d.AppearsIn {
+		result = append(result, xref(in.FullHrefLink()))
+	}
+	return result
+}
+
+func (a *AsciiDocWriter) definition(d *api.Definition, kind string) adocDefinition {
+	data := adocDefinition{
+		ID:          d.LinkID(),
+		Title:       fmt.Sprintf("%s %s %s", kind, d.Version, d.GroupDisplayName()),
+		Group:       d.GroupDisplayName(),
+		Version:     d.Version,
+		Kind:        kind,
+		Description: d.Description(),
+		AppearsIn:   a.appearsIn(d),
+		Fields:      a.fields(d.Fields),
+	}
+	for _, v := range d.OtherVersions {
+		data.OtherVersions = append(data.OtherVersions, xref(v.VersionLink()))
+	}
+	return data
+}
+
+func (a *AsciiDocWriter) WriteDefinition(d *api.Definition) (RenderFunc, error) {
+	return a.include("_"+definitionFileName(d)+".adoc", "definition", func() any {
+		return a.definition(d, d.Name)
+	}), nil
+}
+
+// sampleLang returns the language of a sample, e.g. "yaml" for the type
+// "bdocs-tab:kubectl_yaml".
+func sampleLang(sampleType string) string {
+	lType := strings.Split(sampleType, ":")[1]
+	return strings.Split(lType, "_")[1]
+}
+
+func (a *AsciiDocWriter) operation(o *api.Operation, id, level, title string) adocOperation {
+	data := adocOperation{
+		ID:          id,
+		Level:       level,
+		Title:       title,
+		Description: o.Description(),
+		HTTP:        o.GetDisplayHttp(),
+	}
+
+	if o.Definition != nil {
+		for _, e := range o.GetExampleRequests() {
+			data.RequestSamples = append(data.RequestSamples, adocSample{Title: e.Msg, Lang: sampleLang(e.Type), Text: strings.TrimSpace(e.Text)})
+		}
+		for _, e := range o.GetExampleResponses() {
+			data.ResponseSamples = append(data.ResponseSamples, adocSample{Title: e.Msg, Lang: sampleLang(e.Type), Text: strings.TrimSpace(e.Text)})
+		}
+	}
+
+	for _, params := range []struct {
+		title  string
+		fields api.Fields
+	}{
+		{"Path Parameters", o.PathParams},
+		{"Query Parameters", o.QueryParams},
+		{"Body Parameters", o.BodyParams},
+	} {
+		if params.fields.Len() > 0 {
+			data.Params = append(data.Params, adocParams{Title: params.title, Fields: a.fields(params.fields)})
+		}
+	}
+
+	responses := append(api.HttpResponses(nil), o.HttpResponses...)
+	sort.Slice(responses, func(i, j int) bool {
+		return strings.Compare(responses[i].Name, responses[j].Name) < 0
+	})
+	for _, r := range responses {
+		response := adocField{Name: r.Name, Description: r.Field.Description}
+		if r.Field.Link() != "" {
+			response.Type = xref(r.Field.FullLink())
+		}
+		data.Responses = append(data.Responses, response)
+	}
+
+	return data
+}
+
+func (a *AsciiDocWriter) WriteOperation(o *api.Operation) (RenderFunc, error) {
+	return a.include("_"+operationFileName(o)+".adoc", "operation", func() any {
+		title := o.ID
+		if group, version, kind, _ := o.GetGroupVersionKindSub(); group != "" {
+			title = fmt.Sprintf("%s %s %s", kind, version, group)
+		}
+		return a.operation(o, getLink(o.ID), "===", title)
+	}), nil
+}
+
+func (a *AsciiDocWriter) WriteResource(r *api.Resource) (RenderFunc, error) {
+	return a.include("_"+conceptFileName(r.Definition)+".adoc", "definition", func() any {
+		return a.resource(r)
+	}), nil
+}
+
+// resource returns the data of a resource, with its inline definitions and
+// operations.
+func (a *AsciiDocWriter) resource(r *api.Resource) adocDefinition {
+	d := r.Definition
+	data := a.definition(d, r.Name)
+	data.Warning = r.DescriptionWarning
+	data.Note = r.DescriptionNote
+
+	if d.Sample.Sample != "" {
+		for _, s := range d.GetSamples() {
+			data.Samples = append(data.Samples, adocSample{Title: d.Sample.Note, Lang: sampleLang(s.Type), Text: strings.TrimSpace(s.Text)})
+		}
+	}
+
+	for _, inline := range d.Inline {
+		data.Inline = append(data.Inline, adocInlineDefinition{
+			ID:        inline.LinkID(),
+			Title:     fmt.Sprintf("%s %s %s", inline.Name, inline.Version, inline.Group),
+			AppearsIn: a.appearsIn(inline),
+			Fields:    a.fields(inline.Fields),
+		})
+	}
+
+	for _, oc := range d.OperationCategories {
+		if len(oc.Operations) == 0 {
+			continue
+		}
+		category := adocOperationCategory{
+			ID:   strings.ReplaceAll(strings.ToLower(oc.Name), " ", "-") + "-" + d.LinkID(),
+			Name: oc.Name,
+		}
+		for _, o := range oc.Operations {
+			opID := strings.ReplaceAll(strings.ToLower(o.Type.Name), " ", "-") + "-" + d.LinkID()
+			category.Operations = append(category.Operations, a.operation(o, opID, "=====", o.Type.Name))
+		}
+		data.Categories = append(data.Categories, category)
+	}
+	return data
+}
+
+func (a *AsciiDocWriter) Finalize() error {
+	data := adocIndex{
+		Title:     a.Title,
+		Version:   a.Config.SpecVersion,
+		Copyright: a.Copyright,
+	}
+	for _, fn := range a.includes {
+		data.Includes = append(data.Includes, filepath.ToSlash(filepath.Join(filepath.Base(api.IncludesDir), fn)))
+	}
+
+	content, err := a.render("index", data)
+	if err != nil {
+		return err
+	}
+	index := filepath.Join(api.BuildDir, "index.adoc")
+	if err := os.WriteFile(index, []byte(content), 0644); err != nil {
+		return err
+	}
+
+	if *api.DocBook {
+		return exportDocBook(index)
+	}
+	return nil
+}
+
+// exportDocBook converts the book to DocBook 5 with asciidoctor, writing
+// index.xml next to it.
+func exportDocBook(index string) error {
+	asciidoctor, err := exec.LookPath("asciidoctor")
+	if err != nil {
+		return fmt.Errorf("the DocBook export requires asciidoctor: %w", err)
+	}
+	fmt.Printf("Exporting %s to DocBook\n", index)
+	cmd := exec.Command(asciidoctor, "--backend", "docbook5", "--failure-level", "ERROR", index)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to export %s to DocBook: %w", index, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes-sigs/reference-docs/diagnostics"
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+// TestDiagnostics checks the warnings reported for the fixture, which has
+// no full name for its groups and definitions not referenced by any field
+// or operation, and that --strict fails on them.
+func TestDiagnostics(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "diagnostics.json")
+	setFlag(t, api.DiagnosticsFile, file)
+	generateFixture(t, "markdown")
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("read diagnostics: %v", err)
+	}
+	var report diagnostics.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("parse diagnostics: %v", err)
+	}
+	if report.Clean || report.Errors != 0 {
+		t.Errorf("got clean=%v errors=%d, want warnings only", report.Clean, report.Errors)
+	}
+	codes := map[string]int{}
+	for _, d := range report.Diagnostics {
+		codes[d.Code]++
+	}
+	if want := map[string]int{"guessed-group-name": 11, "orphaned-definition": 2}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("got diagnostics by code %v, want %v", codes, want)
+	}
+
+	setFlag(t, api.Strict, true)
+	if err := GenerateFiles(); err == nil {
+		t.Error("GenerateFiles should fail on warnings with --strict")
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+// goldenBackends are the backends checked against the output trees under
+// testdata/golden. Run `go test -run TestGoldenTrees -update` to rewrite the
+// trees after an intended change of the output.
+var goldenBackends = []string{"html", "html-multipage", "asciidoc", "markdown", "hugo-md"}
+
+// TestGoldenTrees runs GenerateFiles on the fixture under testdata/fixture,
+// a small subset of the Kubernetes API, and compares the whole build tree of
+// each backend with its golden copy, links between files included. The pages
+// are rendered sequentially, then concurrently, which must not change the
+// output.
+func TestGoldenTrees(t *testing.T) {
+	for _, backend := range goldenBackends {
+		for _, workers := range []int{1, 8} {
+			t.Run(fmt.Sprintf("%s/workers=%d", backend, workers), func(t *testing.T) {
+				setFlag(t, api.Workers, workers)
+				got := generateFixture(t, backend)
+				if *update && workers > 1 {
+					t.Skip("the golden trees are written by the sequential run")
+				}
+				compareTreeWithGolden(t, got, filepath.Join("testdata", "golden", backend))
+			})
+		}
+	}
+}
+
+// TestDeterministicOutput generates the fixture under testdata/autodetect,
+// whose categories are detected from the spec and which has groups sorted
+// specially like "core" and "events", twice with each backend and checks
+// that both runs write the same tree, so that rerunning a release gives no
+// spurious diff.
+func TestDeterministicOutput(t *testing.T) {
+	for _, backend := range goldenBackends {
+		t.Run(backend, func(t *testing.T) {
+			first := readTree(t, generate(t, "autodetect", backend, true))
+			second := readTree(t, generate(t, "autodetect", backend, true))
+			compareTrees(t, second, first)
+		})
+	}
+}
+
+// generateFixture runs GenerateFiles on the fixture under testdata/fixture
+// with the backend. It returns the build directory.
+func generateFixture(t *testing.T, backend string) string {
+	t.Helper()
+	return generate(t, "fixture", backend, false)
+}
+
+// generate copies the fixture under testdata into a temporary work directory
+// and runs GenerateFiles on it with the backend, detecting the groups and
+// categories from the spec if autoDetect is set. It returns the build
+// directory.
+func generate(t *testing.T, fixture, backend string, autoDetect bool) string {
+	t.Helper()
+
+	// The paths of the examples are lower-cased by the generator, so the work
+	// directory must not be named after the test like t.TempDir() is.
+	workDir, err := os.MkdirTemp("", "golden")
+	if err != nil {
+		t.Fatalf("create work dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(workDir) })
+	if err := os.CopyFS(workDir, os.DirFS(filepath.Join("testdata", fixture))); err != nil {
+		t.Fatalf("copy fixture: %v", err)
+	}
+
+	setFlag(t, api.WorkDir, workDir)
+	setFlag(t, api.KubernetesRelease, "1.0")
+	setFlag(t, api.Backend, backend)
+	setFlag(t, api.SiteURL, "https://example.com/reference/")
+	setFlag(t, api.AutoDetect, autoDetect)
+	setFlag(t, api.UseTags, false)
+	setFlag(t, api.BuildOps, true)
+
+	saved := now
+	now = func() time.Time { return time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC) }
+	t.Cleanup(func() { now = saved })
+
+	if err := GenerateFiles(); err != nil {
+		t.Fatalf("GenerateFiles with backend %s: %v", backend, err)
+	}
+	return api.BuildDir
+}
+
+// setFlag sets the value of a flag for the duration of the test.
+func setFlag[T any](t *testing.T, flag *T, value T) {
+	t.Helper()
+	saved := *flag
+	*flag = value
+	t.Cleanup(func() { *flag = saved })
+}
+
+// compareTreeWithGolden compares the files under gotDir with the ones under
+// goldenDir, reporting missing, extra and differing files. With -update, the
+// golden tree is replaced with gotDir instead.
+func compareTreeWithGolden(t *testing.T, gotDir, goldenDir string) {
+	t.Helper()
+	if *update {
+		if err := os.RemoveAll(goldenDir); err != nil {
+			t.Fatalf("remove golden tree: %v", err)
+		}
+		if err := os.CopyFS(goldenDir, os.DirFS(gotDir)); err != nil {
+			t.Fatalf("write golden tree: %v", err)
+		}
+		return
+	}
+
+	want := readTree(t, goldenDir)
+	if len(want) == 0 {
+		t.Fatalf("golden tree %s is empty (run `go test -update` to create it)", goldenDir)
+	}
+	compareTrees(t, readTree(t, gotDir), want)
+}
+
+// compareTrees reports the files missing from got, the extra ones and the
+// ones differing from want.
+func compareTrees(t *testing.T, got, want map[string][]byte) {
+	t.Helper()
+	var names []string
+	for name := range got {
+		names = append(names, name)
+	}
+	for name := range want {
+		if _, found := got[name]; !found {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		g, inGot := got[name]
+		w, inWant := want[name]
+		switch {
+		case !inWant:
+			t.Errorf("unexpected file %s", name)
+		case !inGot:
+			t.Errorf("missing file %s", name)
+		case !bytes.Equal(g, w):
+			t.Errorf("mismatch for %s\n%s", name, firstDiff(g, w))
+		}
+	}
+}
+
+// readTree returns the content of the files under dir, keyed by their
+// slash-separated path relative to dir.
+func readTree(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("read tree %s: %v", dir, err)
+	}
+	return files
+}
+
+// firstDiff describes the first line differing between got and want, which
+// is more useful than the whole content of large files.
+func firstDiff(got, want []byte) string {
+	gotLines := bytes.Split(got, []byte("\n"))
+	wantLines := bytes.Split(want, []byte("\n"))
+	for i := 0; i < len(gotLines) || i < len(wantLines); i++ {
+		var g, w []byte
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if !bytes.Equal(g, w) {
+			return fmt.Sprintf("line %d:\n--- got ---\n%s\n--- want ---\n%s", i+1, g, w)
+		}
+	}
+	return ""
+}
@@ -17,16 +17,17 @@ limitations under the License.
 package generators
 
 import (
+	"bytes"
+	"embed"
 	"encoding/json"
 	"fmt"
-	"html"
-	"io"
+	"html/template"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
-	"time"
 
+	"github.com/kubernetes-sigs/reference-docs/diagnostics"
 	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
 )
 
@@ -38,50 +39,65 @@ type TOCItem struct {
 	SubSections []*TOCItem
 }
 
-func (ti *TOCItem) ToHTML() string {
-	childClass := ""
-	if len(ti.SubSections) > 0 {
-		childClass = " has-children"
-	}
-
-	nav := ""
-	nav += fmt.Sprintf("<LI class=\"nav-level level-%d%s\" data-level=\"%d\">\n", ti.Level, childClass, ti.Level)
-	nav += fmt.Sprintf("  <A href=\"#%s\" class=\"nav-item\">%s</A>", ti.Link, ti.Title)
+type TOC struct {
+	Title     string
+	Copyright string
+	Sections  []*TOCItem
+}
 
-	if len(ti.SubSections) > 0 {
-		nav += "\n"
-		nav += fmt.Sprintf("  <UL id=\"%s-nav\">\n", ti.Link)
+//go:embed templates/html/*.tmpl
+var htmlTemplateFS embed.FS
 
-		for _, subItem := range ti.SubSections {
-			nav += subItem.ToHTML()
-			nav += "\n"
-		}
+var htmlFuncs = template.FuncMap{
+	"anchor": func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, " ", "-"))
+	},
+	"join": strings.Join,
+}
 
-		nav += "  </UL>"
+// loadHTMLTemplates parses the embedded templates of the HTML backend, then
+// the *.tmpl files of dir, if set. Templates defined in dir replace the
+// embedded ones with the same name.
+func loadHTMLTemplates(dir string) (*template.Template, error) {
+	t, err := template.New("html").Funcs(htmlFuncs).ParseFS(htmlTemplateFS, "templates/html/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		return t, nil
 	}
 
-	nav += "\n"
-	nav += "</LI>"
-
-	return nav
-}
-
-type TOC struct {
-	Title     string
-	Copyright string
-	Sections  []*TOCItem
+	files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no templates found in %s", dir)
+	}
+	return t.ParseFiles(files...)
 }
 
 type HTMLWriter struct {
 	Config *api.Config
 	TOC    TOC
 
+	templates *template.Template
+
 	// currentTOCItem is used to remember the current item between
 	// calls to e.g. WriteResourceCategory() followed by WriteResource().
 	currentTOCItem *TOCItem
 }
 
-func NewHTMLWriter(config *api.Config, copyright, title string) DocWriter {
+func NewHTMLWriter(config *api.Config, copyright, title string) (DocWriter, error) {
+	return newHTMLWriter(config, copyright, title)
+}
+
+func newHTMLWriter(config *api.Config, copyright, title string) (*HTMLWriter, error) {
+	templates, err := loadHTMLTemplates(*api.TemplatesDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load HTML templates: %w", err)
+	}
+
 	writer := HTMLWriter{
 		Config: config,
 		TOC: TOC{
@@ -89,17 +105,144 @@ func NewHTMLWriter(config *api.Config, copyright, title string) DocWriter {
 			Title:     title,
 			Sections:  []*TOCItem{},
 		},
+		templates: templates,
 	}
-	return &writer
+	return &writer, nil
+}
+
+// The types below are the data passed to the templates. Values which are
+// already HTML, such as descriptions and links, are of type template.HTML.
+
+type htmlGVK struct {
+	Group   string
+	Version api.ApiVersion
+	Kind    string
+}
+
+type htmlField struct {
+	Name          string
+	Link          template.HTML
+	PatchStrategy string
+	PatchMergeKey string
+	Description   template.HTML
+}
+
+type htmlDefinition struct {
+	ID            string
+	Title         template.HTML
+	GVK           htmlGVK
+	Description   template.HTML
+	OtherVersions []template.HTML
+	AppearsIn     []template.HTML
+	Fields        []htmlField
+}
+
+type htmlInlineDefinition struct {
+	ID        string
+	Title     string
+	AppearsIn []template.HTML
+	Fields    []htmlField
+}
+
+type htmlSample struct {
+	ID string
+	// Type is the tab of the sample, e.g. "kubectl" or "curl".
+	Type string
+	// Kind is "request" or "response" for the samples of an operation,
+	// empty for the samples of a resource.
+	Kind    string
+	Heading template.HTML
+	Lang    string
+	Text    string
+}
+
+type htmlParams struct {
+	Title  string
+	Fields []htmlField
+}
+
+type htmlOperation struct {
+	ID              string
+	Title           template.HTML
+	RequestSamples  []htmlSample
+	ResponseSamples []htmlSample
+	Description     template.HTML
+	HTTP            string
+	Params          []htmlParams
+	Responses       []htmlField
+}
+
+type htmlOperationCategory struct {
+	ID         string
+	Name       string
+	Operations []htmlOperation
+}
+
+type htmlResource struct {
+	htmlDefinition
+	Samples    []htmlSample
+	Warning    template.HTML
+	Note       template.HTML
+	Inline     []htmlInlineDefinition
+	Categories []htmlOperationCategory
+}
+
+type htmlNavItem struct {
+	Level int
+	Title template.HTML
+	Link  string
+	// Href and Current are only set in the navigation of multiple pages.
+	Href        string
+	Current     bool
+	SubSections []htmlNavItem
+}
+
+type htmlFooter struct {
+	Copyright   template.HTML
+	GeneratedAt string
+	SpecLink    string
+	SpecVersion string
+}
+
+type htmlIndex struct {
+	htmlFooter
+	Title    string
+	Nav      []htmlNavItem
+	Includes []template.HTML
+}
+
+func (h *HTMLWriter) render(name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := h.templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// writeInclude renders a template into a file of the includes directory.
+func (h *HTMLWriter) writeInclude(fn, name string, data any) error {
+	content, err := h.render(name, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", fn, err)
+	}
+	return os.WriteFile(filepath.Join(api.IncludesDir, fn), []byte(content), 0644)
 }
 
 func (h *HTMLWriter) Extension() string {
 	return ".html"
 }
 
+func (h *HTMLWriter) writeSection(filename, title string) error {
+	heading, err := h.render("section-heading", title)
+	if err != nil {
+		return err
+	}
+	return writeStaticFile(filename, heading)
+}
+
 func (h *HTMLWriter) WriteOverview() error {
 	filename := "_overview.html"
-	if err := writeStaticFile(filename, h.SectionHeading("API Overview")); err != nil {
+	if err := h.writeSection(filename, "API Overview"); err != nil {
 		return err
 	}
 
@@ -117,37 +260,9 @@ func (h *HTMLWriter) WriteOverview() error {
 
 func (h *HTMLWriter) WriteAPIGroupVersions(gvs api.GroupVersions) error {
 	fn := "_group_versions.html"
-	path := filepath.Join(api.IncludesDir, fn)
-	f, err := os.Create(path)
-	if err != nil {
+	if err := h.writeInclude(fn, "group-versions", sortedGroupVersions(gvs)); err != nil {
 		return err
 	}
-	defer f.Close()
-
-	fmt.Fprint(f, "<DIV id=\"api-groups\">\n")
-	fmt.Fprint(f, h.SectionHeading("API Groups")+"\n")
-	fmt.Fprint(f, "<P>The API Groups and their versions are summarized in the following table.</P>\n")
-	fmt.Fprint(f, "<TABLE class=\"col-md-8\">\n<THEAD><TR><TH>Group</TH><TH>Versions</TH></TR></THEAD>\n<TBODY>\n")
-
-	groups := api.ApiGroups{}
-	for group := range gvs {
-		groups = append(groups, api.ApiGroup(group))
-	}
-	sort.Sort(groups)
-
-	for _, group := range groups {
-		versionList := gvs[group.String()]
-		sort.Sort(versionList)
-		var versions []string
-		for _, v := range versionList {
-			versions = append(versions, v.String())
-		}
-
-		fmt.Fprintf(f, "<TR><TD><CODE>%s</CODE></TD><TD><CODE>%s</CODE></TD></TR>\n",
-			group, strings.Join(versions, ", "))
-	}
-	fmt.Fprint(f, "</TBODY>\n</TABLE>\n")
-	fmt.Fprint(f, "</DIV>\n")
 
 	item := TOCItem{
 		Level: 1,
@@ -162,7 +277,11 @@ func (h *HTMLWriter) WriteAPIGroupVersions(gvs api.GroupVersions) error {
 }
 
 func (h *HTMLWriter) WriteResourceCategory(name, file string) error {
-	if err := writeStaticFile("_"+file+".html", h.ResourceCategoryHeading(name)); err != nil {
+	heading, err := h.render("resource-category-heading", name)
+	if err != nil {
+		return err
+	}
+	if err := writeStaticFile("_"+file+".html", heading); err != nil {
 		return err
 	}
 
@@ -179,63 +298,65 @@ func (h *HTMLWriter) WriteResourceCategory(name, file string) error {
 	return nil
 }
 
-func (h *HTMLWriter) ResourceCategoryHeading(title string) string {
-	sectionID := strings.ToLower(strings.ReplaceAll(title, " ", "-"))
-	return fmt.Sprintf(`<H1 class="toc-item resource-category" id="%s">%s</H1>`, sectionID, title)
-}
-
-func (h *HTMLWriter) SectionHeading(title string) string {
-	return fmt.Sprintf(`<H1 class="toc-item section">%s</H1>`, title)
-}
-
 func (h *HTMLWriter) DefaultStaticContent(title string) string {
-	titleID := strings.ToLower(strings.ReplaceAll(title, " ", "-"))
-	return fmt.Sprintf("<H1 class=\"strong\" id=\"%s\">%s</H1>\n", titleID, title)
-}
-
-func (h *HTMLWriter) writeOtherVersions(w io.Writer, d *api.Definition) {
-	if d.OtherVersions.Len() == 0 {
-		return
+	content, err := h.render("static-content", title)
+	if err != nil {
+		api.Diagnostics.Errorf("render-failed", diagnostics.Location{}, "failed to render the content of %s: %v", title, err)
 	}
+	return content
+}
 
-	fmt.Fprint(w, "<DIV class=\"alert alert-success col-md-8\"><I class=\"fa fa-toggle-right\"></I> Other API versions of this object exist:\n")
+func (h *HTMLWriter) otherVersions(d *api.Definition) []template.HTML {
+	var result []template.HTML
 	for _, v := range d.OtherVersions {
-		fmt.Fprintf(w, "%s\n", v.VersionLink())
+		result = append(result, template.HTML(v.VersionLink()))
 	}
-	fmt.Fprintf(w, "</DIV>\n")
+	return result
 }
 
-func (h *HTMLWriter) writeAppearsIn(w io.Writer, d *api.Definition) {
-	if d.AppearsIn.Len() != 0 {
-		fmt.Fprintf(w, "<DIV class=\"alert alert-info col-md-8\"><I class=\"fa fa-info-circle\"></I> Appears In:\n <UL>\n")
-		for _, a := range d.AppearsIn {
-			fmt.Fprintf(w, "  <LI>%s</LI>\n", a.FullHrefLink())
-		}
-		fmt.Fprintf(w, " </UL>\n</DIV>\n")
+func (h *HTMLWriter) appearsIn(d *api.Definition) []template.HTML {
+	var result []template.HTML
+	for _, a := range d.AppearsIn {
+		result = append(result, template.HTML(a.FullHrefLink()))
 	}
+	return result
 }
 
-func (h *HTMLWriter) writeFields(w io.Writer, d *api.Definition) {
-	fmt.Fprintf(w, "<TABLE>\n<THEAD><TR><TH>Field</TH><TH>Description</TH></TR></THEAD>\n<TBODY>\n")
-
+func (h *HTMLWriter) fields(d *api.Definition) []htmlField {
+	var result []htmlField
 	for _, field := range d.Fields {
-		fmt.Fprintf(w, "<TR><TD><CODE>%s</CODE>", field.Name)
-		if field.Link() != "" {
-			fmt.Fprintf(w, "<BR /><I>%s</I>", field.FullLink())
-		}
-		if field.PatchStrategy != "" {
-			fmt.Fprintf(w, "<BR /><B>patch strategy</B>: <I>%s</I>", field.PatchStrategy)
+		f := htmlField{
+			Name:          field.Name,
+			PatchStrategy: field.PatchStrategy,
+			PatchMergeKey: field.PatchMergeKey,
+			Description:   template.HTML(field.DescriptionWithEntities),
 		}
-		if field.PatchMergeKey != "" {
-			fmt.Fprintf(w, "<BR /><B>patch merge key</B>: <I>%s</I>", field.PatchMergeKey)
+		if field.Link() != "" {
+			f.Link = template.HTML(field.FullLink())
 		}
-		fmt.Fprintf(w, "</TD><TD>%s</TD></TR>\n", field.DescriptionWithEntities)
+		result = append(result, f)
+	}
+	return result
+}
+
+func (h *HTMLWriter) definition(d *api.Definition, kind string, linkID string) (htmlDefinition, error) {
+	title, err := h.gvkMarkup(d.GroupDisplayName(), d.Version, kind)
+	if err != nil {
+		return htmlDefinition{}, err
 	}
-	fmt.Fprintf(w, "</TBODY>\n</TABLE>\n")
+	return htmlDefinition{
+		ID:            linkID,
+		Title:         template.HTML(title),
+		GVK:           htmlGVK{Group: d.GroupDisplayName(), Version: d.Version, Kind: kind},
+		Description:   template.HTML(d.DescriptionWithEntities),
+		OtherVersions: h.otherVersions(d),
+		AppearsIn:     h.appearsIn(d),
+		Fields:        h.fields(d),
+	}, nil
 }
 
 func (h *HTMLWriter) WriteDefinitionsOverview() error {
-	if err := writeStaticFile("_definitions.html", h.SectionHeading("Definitions")); err != nil {
+	if err := h.writeSection("_definitions.html", "Definitions"); err != nil {
 		return err
 	}
 
@@ -252,7 +373,7 @@ func (h *HTMLWriter) WriteDefinitionsOverview() error {
 }
 
 func (h *HTMLWriter) WriteOrphanedOperationsOverview() error {
-	if err := writeStaticFile("_operations.html", h.SectionHeading("Operations")); err != nil {
+	if err := h.writeSection("_operations.html", "Operations"); err != nil {
 		return err
 	}
 
@@ -268,57 +389,39 @@ func (h *HTMLWriter) WriteOrphanedOperationsOverview() error {
 	return nil
 }
 
-func (h *HTMLWriter) WriteDefinition(d *api.Definition) error {
+func (h *HTMLWriter) WriteDefinition(d *api.Definition) (RenderFunc, error) {
 	fn := "_" + definitionFileName(d) + ".html"
-	path := filepath.Join(api.IncludesDir, fn)
-	f, err := os.Create(path)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
 
 	nvg := fmt.Sprintf("%s %s %s", d.Name, d.Version, d.GroupDisplayName())
 	linkID := getLink(nvg)
-	nvg = h.gvkMarkup(d.GroupDisplayName(), d.Version, d.Name)
-
-	fmt.Fprintf(f, "<DIV class=\"definition-container\" id=\"%s\">\n", linkID)
-	defer fmt.Fprint(f, "</DIV>\n")
 
-	fmt.Fprintf(f, "<H2 class=\"definition\">%s</H2>\n", nvg)
-
-	// GVK
-	fmt.Fprintf(f, "<TABLE class=\"col-md-8\">\n<THEAD><TR><TH>Group</TH><TH>Version</TH><TH>Kind</TH></TR></THEAD>\n<TBODY>\n")
-	fmt.Fprintf(f, "<TR><TD><CODE>%s</CODE></TD><TD><CODE>%s</CODE></TD><TD><CODE>%s</CODE></TD></TR>\n",
-		d.GroupDisplayName(), d.Version, d.Name)
-	fmt.Fprintf(f, "</TBODY>\n</TABLE>\n")
-
-	fmt.Fprintf(f, "<P>%s</P>\n", d.DescriptionWithEntities)
-	h.writeOtherVersions(f, d)
-	h.writeAppearsIn(f, d)
-	h.writeFields(f, d)
+	title, err := h.gvkMarkup(d.GroupDisplayName(), d.Version, d.Name)
+	if err != nil {
+		return nil, err
+	}
 
 	// Definitions are added to the TOC to enable the generator to later collect
 	// all the individual definition files, but definitions will not show up
 	// in the nav treet because it would take up too much screen estate.
 	item := TOCItem{
 		Level: 2,
-		Title: nvg,
+		Title: title,
 		Link:  linkID,
 		File:  fn,
 	}
 	h.currentTOCItem.SubSections = append(h.currentTOCItem.SubSections, &item)
 
-	return nil
+	return func() error {
+		data, err := h.definition(d, d.Name, linkID)
+		if err != nil {
+			return err
+		}
+		return h.writeInclude(fn, "definition", data)
+	}, nil
 }
 
-func (h *HTMLWriter) WriteOperation(o *api.Operation) error {
+func (h *HTMLWriter) WriteOperation(o *api.Operation) (RenderFunc, error) {
 	fn := "_" + operationFileName(o) + ".html"
-	path := filepath.Join(api.IncludesDir, fn)
-	f, err := os.Create(path)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
 
 	nvg := o.ID
 	linkID := getLink(nvg)
@@ -327,14 +430,13 @@ func (h *HTMLWriter) WriteOperation(o *api.Operation) error {
 	oApiVersion := api.ApiVersion(oVersion)
 
 	if len(oGroup) > 0 {
-		nvg = h.gvkMarkup(oGroup, oApiVersion, oKind)
+		markup, err := h.gvkMarkup(oGroup, oApiVersion, oKind)
+		if err != nil {
+			return nil, err
+		}
+		nvg = markup
 	}
 
-	fmt.Fprintf(f, "<DIV class=\"operation-container\" id=\"%s\">\n", linkID)
-	defer fmt.Fprint(f, "</DIV>\n")
-
-	fmt.Fprintf(f, "<H2 class=\"toc-item operation\">%s</H2>\n", nvg)
-
 	item := TOCItem{
 		Level: 2,
 		Title: nvg,
@@ -343,75 +445,48 @@ func (h *HTMLWriter) WriteOperation(o *api.Operation) error {
 	}
 	h.currentTOCItem.SubSections = append(h.currentTOCItem.SubSections, &item)
 
-	h.WriteOperationBody(f, o, o.ID)
-
-	return nil
+	return func() error {
+		return h.writeInclude(fn, "operation", h.operation(o, linkID, nvg, o.ID))
+	}, nil
 }
 
-func (h *HTMLWriter) writeSamples(w io.Writer, d *api.Definition) {
+func (h *HTMLWriter) samples(d *api.Definition) []htmlSample {
 	if d.Sample.Sample == "" {
-		return
-	}
-
-	fmt.Fprintf(w, "<DIV class=\"samples-container\">\n")
-	fmt.Fprintf(w, "<P>\n")
-
-	note := d.Sample.Note
-	for _, s := range d.GetSamples() {
-		sType := strings.Split(s.Tab, ":")[1]
-		linkID := sType + "-" + d.LinkID()
-		fmt.Fprintf(w, "<BUTTON class=\"btn btn-info\" type=\"button\" data-bs-toggle=\"collapse\"\n")
-		fmt.Fprintf(w, "  data-bs-target=\"#%s\" aria-controls=\"%s\"\n", linkID, linkID)
-		fmt.Fprintf(w, "  aria-expanded=\"false\">show %s</BUTTON>\n", sType)
+		return nil
 	}
 
-	fmt.Fprintf(w, "</P>\n")
-
+	var result []htmlSample
 	for _, s := range d.GetSamples() {
 		sType := strings.Split(s.Tab, ":")[1]
-		linkID := sType + "-" + d.LinkID()
 		lType := strings.Split(s.Type, ":")[1]
-		lang := strings.Split(lType, "_")[1]
-		fmt.Fprintf(w, "<DIV class=\"collapse\" id=\"%s\">\n", linkID)
-		fmt.Fprintf(w, "  <DIV class=\"panel panel-default\">\n<DIV class=\"panel-heading\">%s</DIV>\n", note)
-		fmt.Fprintf(w, "  <DIV class=\"panel-body\">\n<PRE class=\"%s\">", sType)
-		fmt.Fprintf(w, "<CODE class=\"lang-%s\">", lang)
-		// TODO: Add language highlight
-		fmt.Fprintf(w, "%s</CODE></PRE></DIV></DIV></DIV>\n", strings.TrimSpace(html.EscapeString(s.Text)))
+		result = append(result, htmlSample{
+			ID:      sType + "-" + d.LinkID(),
+			Type:    sType,
+			Heading: template.HTML(d.Sample.Note),
+			Lang:    strings.Split(lType, "_")[1],
+			// TODO: Add language highlight
+			Text: strings.TrimSpace(s.Text),
+		})
 	}
-
-	fmt.Fprint(w, "</DIV>\n")
+	return result
 }
 
-func (h *HTMLWriter) writeOperationSample(w io.Writer, req bool, op string, examples []api.ExampleText) {
+func (h *HTMLWriter) operationSamples(req bool, op string, examples []api.ExampleText) []htmlSample {
 	// e.Tab bdocs-tab:kubectl  | bdocs-tab:curl
 	// e.Msg `kubectl` Command  | Output | Response Body | `curl` Command (*requires `kubectl proxy` to be running*)
 	// e.Type bdocs-tab:kubectl_shell
 	// e.Text <actual command>
 
+	var result []htmlSample
 	for _, e := range examples {
 		eType := strings.Split(e.Tab, ":")[1]
-		var sampleID string
-		var btnText string
-		if req {
-			sampleID = "req-" + eType + "-" + op
-			btnText = eType + " request"
-		} else {
-			sampleID = "res-" + eType + "-" + op
-			btnText = eType + " response"
-		}
-		fmt.Fprintf(w, "<BUTTON class=\"btn btn-info\" type=\"button\" data-bs-toggle=\"collapse\"\n")
-		fmt.Fprintf(w, "  data-bs-target=\"#%s\" aria-controls=\"%s\"\n", sampleID, sampleID)
-		fmt.Fprintf(w, "  aria-expanded=\"false\">%s example</BUTTON>\n", btnText)
-	}
-
-	for _, e := range examples {
-		eType := strings.Split(e.Tab, ":")[1]
-		var sampleID string
+		var sampleID, kind string
 		if req {
 			sampleID = "req-" + eType + "-" + op
+			kind = "request"
 		} else {
 			sampleID = "res-" + eType + "-" + op
+			kind = "response"
 		}
 		msg := e.Msg
 		if eType == "curl" && strings.Contains(msg, "proxy") {
@@ -420,192 +495,164 @@ func (h *HTMLWriter) writeOperationSample(w io.Writer, req bool, op string, exam
 			msg = "<CODE>kubectl</CODE> command"
 		}
 		lType := strings.Split(e.Type, ":")[1]
-		lang := strings.Split(lType, "_")[1]
-		fmt.Fprintf(w, "<DIV class=\"collapse\" id=\"%s\">\n", sampleID)
-		fmt.Fprintf(w, "  <DIV class=\"panel panel-default\">\n<DIV class=\"panel-heading\">%s</DIV>\n", msg)
-		fmt.Fprintf(w, "  <DIV class=\"panel-body\">\n<PRE class=\"%s\">", eType)
-		fmt.Fprintf(w, "<CODE class=\"lang-%s\">", lang)
-		// TODO: Add language highlight
-		fmt.Fprintf(w, "%s</CODE></PRE></DIV></DIV></DIV>\n", strings.TrimSpace(e.Text))
+		result = append(result, htmlSample{
+			ID:      sampleID,
+			Type:    eType,
+			Kind:    kind,
+			Heading: template.HTML(msg),
+			Lang:    strings.Split(lType, "_")[1],
+			// TODO: Add language highlight
+			Text: strings.TrimSpace(e.Text),
+		})
 	}
+	return result
 }
 
-func (h *HTMLWriter) writeParams(w io.Writer, title string, params api.Fields) {
-	fmt.Fprintf(w, "<H3>%s</H3>\n", title)
-	fmt.Fprintf(w, "<TABLE>\n<THEAD><TR><TH>Parameter</TH><TH>Description</TH></TR></THEAD>\n<TBODY>\n")
+func (h *HTMLWriter) params(title string, params api.Fields) htmlParams {
+	result := htmlParams{Title: title}
 	for _, p := range params {
-		fmt.Fprintf(w, "<TR><TD><CODE>%s</CODE>", p.Name)
+		f := htmlField{Name: p.Name, Description: template.HTML(p.Description)}
 		if p.Link() != "" {
-			fmt.Fprintf(w, "<br /><I>%s</I>", p.FullLink())
+			f.Link = template.HTML(p.FullLink())
 		}
-		fmt.Fprintf(w, "</TD><TD>%s</TD></TR>\n", p.Description)
+		result.Fields = append(result.Fields, f)
 	}
-	fmt.Fprintf(w, "</TBODY>\n</TABLE>\n")
+	return result
 }
 
-func (h *HTMLWriter) writeRequestParams(w io.Writer, o *api.Operation) {
-	// Operation path params
-	if o.PathParams.Len() > 0 {
-		h.writeParams(w, "Path Parameters", o.PathParams)
+// operation returns the data of an operation, opID being the prefix of the
+// IDs of its samples.
+func (h *HTMLWriter) operation(o *api.Operation, linkID, title, opID string) htmlOperation {
+	data := htmlOperation{
+		ID:          linkID,
+		Title:       template.HTML(title),
+		Description: template.HTML(o.Description()),
+		HTTP:        o.GetDisplayHttp(),
 	}
 
-	// operation query params
-	if o.QueryParams.Len() > 0 {
-		h.writeParams(w, "Query Parameters", o.QueryParams)
+	if o.Definition != nil {
+		data.RequestSamples = h.operationSamples(true, opID, o.GetExampleRequests())
+		data.ResponseSamples = h.operationSamples(false, opID, o.GetExampleResponses())
 	}
 
-	// operation body params
-	if o.BodyParams.Len() > 0 {
-		h.writeParams(w, "Body Parameters", o.BodyParams)
+	if o.PathParams.Len() > 0 {
+		data.Params = append(data.Params, h.params("Path Parameters", o.PathParams))
 	}
-}
-
-func (h *HTMLWriter) writeResponseParams(w io.Writer, o *api.Operation) {
-	if o.HttpResponses.Len() == 0 {
-		return
+	if o.QueryParams.Len() > 0 {
+		data.Params = append(data.Params, h.params("Query Parameters", o.QueryParams))
+	}
+	if o.BodyParams.Len() > 0 {
+		data.Params = append(data.Params, h.params("Body Parameters", o.BodyParams))
 	}
 
-	fmt.Fprintf(w, "<H3>Response</H3>\n")
-	fmt.Fprintf(w, "<TABLE>\n<THEAD><TR><TH>Code</TH><TH>Description</TH></TR></THEAD>\n<TBODY>\n")
-	responses := o.HttpResponses
+	responses := append(api.HttpResponses(nil), o.HttpResponses...)
 	sort.Slice(responses, func(i, j int) bool {
 		return strings.Compare(responses[i].Name, responses[j].Name) < 0
 	})
 	for _, p := range responses {
-		fmt.Fprintf(w, "<TR><TD>%s", p.Name)
+		f := htmlField{Name: p.Name, Description: template.HTML(p.Field.Description)}
 		if p.Field.Link() != "" {
-			fmt.Fprintf(w, "<br /><I>%s</I>", p.Field.FullLink())
-		}
-		fmt.Fprintf(w, "</TD><TD>%s</TD></TR>\n", p.Field.Description)
-	}
-	fmt.Fprintf(w, "</TBODY>\n</TABLE>\n")
-}
-
-func (h *HTMLWriter) WriteOperationBody(w io.Writer, o *api.Operation, opID string) {
-	if o.Definition != nil {
-		// Example requests
-		requests := o.GetExampleRequests()
-		if len(requests) > 0 {
-			h.writeOperationSample(w, true, opID, requests)
-		}
-		// Example responses
-		responses := o.GetExampleResponses()
-		if len(responses) > 0 {
-			h.writeOperationSample(w, false, opID, responses)
+			f.Link = template.HTML(p.Field.FullLink())
 		}
+		data.Responses = append(data.Responses, f)
 	}
 
-	fmt.Fprintf(w, "<P>%s</P>\n", o.Description())
-	fmt.Fprintf(w, "<H3>HTTP Request</H3>\n")
-	fmt.Fprintf(w, "<p><CODE>%s</CODE></P>\n", o.GetDisplayHttp())
-
-	h.writeRequestParams(w, o)
-	h.writeResponseParams(w, o)
+	return data
 }
 
-func (h *HTMLWriter) WriteResource(r *api.Resource) error {
+func (h *HTMLWriter) WriteResource(r *api.Resource) (RenderFunc, error) {
 	fn := "_" + conceptFileName(r.Definition) + ".html"
-	path := filepath.Join(api.IncludesDir, fn)
-
-	w, err := os.Create(path)
-	if err != nil {
-		return err
-	}
-	defer w.Close()
 
 	dvg := fmt.Sprintf("%s %s %s", r.Name, r.Definition.Version, r.Definition.GroupDisplayName())
 	linkID := getLink(dvg)
-	dvg = h.gvkMarkup(r.Definition.GroupDisplayName(), r.Definition.Version, r.Name)
-
-	fmt.Fprintf(w, "<DIV class=\"resource-container\" id=\"%s\">\n", linkID)
-	defer fmt.Fprint(w, "</DIV>\n")
-
-	fmt.Fprintf(w, "<H1 class=\"toc-item resource\">%s</H1>\n", dvg)
 
-	h.writeSamples(w, r.Definition)
-
-	// GVK
-	fmt.Fprintf(w, "<TABLE class=\"col-md-8\">\n<THEAD><TR><TH>Group</TH><TH>Version</TH><TH>Kind</TH></TR></THEAD>\n<TBODY>\n")
-	fmt.Fprintf(w, "<TR><TD><CODE>%s</CODE></TD><TD><CODE>%s</CODE></TD><TD><CODE>%s</CODE></TD></TR>\n",
-		r.Definition.GroupDisplayName(), r.Definition.Version, r.Name)
-	fmt.Fprintf(w, "</TBODY>\n</TABLE>\n")
-
-	if r.DescriptionWarning != "" {
-		fmt.Fprintf(w, "<DIV class=\"alert alert-warning col-md-8\"><P><I class=\"fa fa-warning\"></I> <B>Warning:</B></P><P>%s</P></DIV>\n", r.DescriptionWarning)
-	}
-	if r.DescriptionNote != "" {
-		fmt.Fprintf(w, "<DIV class=\"alert alert-info col-md-8\"><I class=\"fa fa-bullhorn\"></I> %s</DIV>\n", r.DescriptionNote)
-	}
-
-	h.writeOtherVersions(w, r.Definition)
-	h.writeAppearsIn(w, r.Definition)
-	h.writeFields(w, r.Definition)
-
-	// Inline
-	if r.Definition.Inline.Len() > 0 {
-		fmt.Fprintf(w, "<DIV class=\"inline-definitions-container\">\n")
-		for _, d := range r.Definition.Inline {
-			fmt.Fprintf(w, "<H3 class=\"inline-definition\" id=\"%s\">%s %s %s</H3>\n", d.LinkID(), d.Name, d.Version, d.Group)
-			h.writeAppearsIn(w, d)
-			h.writeFields(w, d)
-		}
-		fmt.Fprint(w, "</DIV>\n")
+	title, err := h.gvkMarkup(r.Definition.GroupDisplayName(), r.Definition.Version, r.Name)
+	if err != nil {
+		return nil, err
 	}
-
 	resourceItem := TOCItem{
 		Level: 2,
-		Title: dvg,
+		Title: title,
 		Link:  linkID,
 		File:  fn,
 	}
 	h.currentTOCItem.SubSections = append(h.currentTOCItem.SubSections, &resourceItem)
 
 	// Operations
-	if len(r.Definition.OperationCategories) == 0 {
-		return nil
-	}
-
 	for _, oc := range r.Definition.OperationCategories {
 		if len(oc.Operations) == 0 {
 			continue
 		}
 
-		catID := strings.ReplaceAll(strings.ToLower(oc.Name), " ", "-") + "-" + r.Definition.LinkID()
-		fmt.Fprintf(w, "<DIV class=\"operation-category-container\" id=\"%s\">\n", catID)
-		fmt.Fprintf(w, "<H2 class=\"toc-item operation-category\">%s</H2>\n", oc.Name)
-
 		ocItem := TOCItem{
 			Level: 3,
 			Title: oc.Name,
-			Link:  catID,
+			Link:  strings.ReplaceAll(strings.ToLower(oc.Name), " ", "-") + "-" + r.Definition.LinkID(),
 		}
 		resourceItem.SubSections = append(resourceItem.SubSections, &ocItem)
 
 		for _, o := range oc.Operations {
-			opID := strings.ReplaceAll(strings.ToLower(o.Type.Name), " ", "-") + "-" + r.Definition.LinkID()
-			fmt.Fprintf(w, "<DIV class=\"operation-container\" id=\"%s\">\n", opID)
-			fmt.Fprintf(w, "<H2 class=\"toc-item operation\">%s</H2>\n", o.Type.Name)
-
 			OPItem := TOCItem{
 				Level: 4,
 				Title: o.Type.Name,
-				Link:  opID,
+				Link:  strings.ReplaceAll(strings.ToLower(o.Type.Name), " ", "-") + "-" + r.Definition.LinkID(),
 			}
 			ocItem.SubSections = append(ocItem.SubSections, &OPItem)
+		}
+	}
 
-			h.WriteOperationBody(w, o, opID)
-
-			fmt.Fprint(w, "</DIV>\n")
+	return func() error {
+		data, err := h.resource(r, linkID)
+		if err != nil {
+			return err
 		}
+		return h.writeInclude(fn, "resource", data)
+	}, nil
+}
 
-		fmt.Fprint(w, "</DIV>\n")
+// resource returns the data of a resource, with its inline definitions and
+// operations.
+func (h *HTMLWriter) resource(r *api.Resource, linkID string) (htmlResource, error) {
+	definition, err := h.definition(r.Definition, r.Name, linkID)
+	if err != nil {
+		return htmlResource{}, err
+	}
+	data := htmlResource{
+		htmlDefinition: definition,
+		Samples:        h.samples(r.Definition),
+		Warning:        template.HTML(r.DescriptionWarning),
+		Note:           template.HTML(r.DescriptionNote),
 	}
 
-	return nil
+	// Inline
+	for _, d := range r.Definition.Inline {
+		data.Inline = append(data.Inline, htmlInlineDefinition{
+			ID:        d.LinkID(),
+			Title:     fmt.Sprintf("%s %s %s", d.Name, d.Version, d.Group),
+			AppearsIn: h.appearsIn(d),
+			Fields:    h.fields(d),
+		})
+	}
+
+	// Operations
+	for _, oc := range r.Definition.OperationCategories {
+		if len(oc.Operations) == 0 {
+			continue
+		}
+
+		catID := strings.ReplaceAll(strings.ToLower(oc.Name), " ", "-") + "-" + r.Definition.LinkID()
+		category := htmlOperationCategory{ID: catID, Name: oc.Name}
+		for _, o := range oc.Operations {
+			opID := strings.ReplaceAll(strings.ToLower(o.Type.Name), " ", "-") + "-" + r.Definition.LinkID()
+			category.Operations = append(category.Operations, h.operation(o, opID, o.Type.Name, opID))
+		}
+		data.Categories = append(data.Categories, category)
+	}
+	return data, nil
 }
 
 func (h *HTMLWriter) WriteOldVersionsOverview() error {
-	if err := writeStaticFile("_oldversions.html", h.SectionHeading("Old API Versions")); err != nil {
+	if err := h.writeSection("_oldversions.html", "Old API Versions"); err != nil {
 		return err
 	}
 
@@ -621,17 +668,19 @@ func (h *HTMLWriter) WriteOldVersionsOverview() error {
 	return nil
 }
 
-func (h *HTMLWriter) generateNavContent() string {
-	nav := "<UL id=\"navigation\">\n"
-
-	for _, sec := range h.TOC.Sections {
-		nav += sec.ToHTML()
-		nav += "\n"
+// navItems returns the navigation tree of the TOC items. The titles of the
+// items are HTML.
+func navItems(items []*TOCItem) []htmlNavItem {
+	var result []htmlNavItem
+	for _, item := range items {
+		result = append(result, htmlNavItem{
+			Level:       item.Level,
+			Title:       template.HTML(item.Title),
+			Link:        item.Link,
+			SubSections: navItems(item.SubSections),
+		})
 	}
-
-	nav += "</UL>\n"
-
-	return nav
+	return result
 }
 
 func (h *HTMLWriter) generateNavDataJS() error {
@@ -649,96 +698,69 @@ func (h *HTMLWriter) generateNavDataJS() error {
 	return err
 }
 
-func (h *HTMLWriter) generateIndex(navContent string) error {
-	html, err := os.Create(filepath.Join(api.BuildDir, "index.html"))
-	if err != nil {
-		return err
-	}
-	defer html.Close()
-
-	/* Make sure the following stylesheets exist in kubernetes/website repo:
-	   kubernetes/website/static/css/bootstrap-5.3.2.min.css
-	   kubernetes/website/static/css/fontawesome-4.7.0.min.css
-	   kubernetes/website/static/css/style_apiref.css
-	*/
-	fmt.Fprintf(html, "<!DOCTYPE html>\n<HTML lang=\"en\">\n<HEAD>\n<META charset=\"UTF-8\">\n")
-	fmt.Fprintf(html, "<TITLE>%s</TITLE>\n", h.TOC.Title)
-	fmt.Fprintf(html, "<LINK rel=\"shortcut icon\" href=\"favicon.ico\" type=\"image/vnd.microsoft.icon\">\n")
-	fmt.Fprintf(html, "<LINK rel=\"stylesheet\" href=\"/css/bootstrap-5.3.2.min.css\" type=\"text/css\">\n")
-	fmt.Fprintf(html, "<LINK rel=\"stylesheet\" href=\"/css/fontawesome-4.7.0.min.css\" type=\"text/css\">\n")
-	fmt.Fprintf(html, "<LINK rel=\"stylesheet\" href=\"/css/style_apiref.css\" type=\"text/css\">\n")
-	fmt.Fprintf(html, "</HEAD>\n<BODY class=\"theme-auto\">\n")
-	fmt.Fprintf(html, "<DIV id=\"wrapper\" class=\"container-fluid\">\n")
-	fmt.Fprintf(html, "<DIV class=\"row\">\n")
-	fmt.Fprintf(html, "<DIV id=\"sidebar-wrapper\" class=\"col-xs-4 col-sm-3 col-md-2 side-nav side-bar-nav\">\n")
-
-	// html buffer
-	buf := "<DIV class=\"row\">\n  <DIV class=\"col-md-6 copyright\">\n " + h.TOC.Copyright + "\n  </DIV>\n"
-	buf += "  <DIV class=\"col-md-6 text-right\">\n"
-	buf += fmt.Sprintf("    <DIV>Generated at: %s</DIV>\n", time.Now().Format("2006-01-02 15:04:05 (MST)"))
-	pos := strings.LastIndex(h.Config.SpecVersion, ".")
-	release := fmt.Sprintf("release-%s", h.Config.SpecVersion[1:pos])
-	spec_link := "https://github.com/kubernetes/kubernetes/blob/" + release + "/api/openapi-spec/swagger.json"
-	buf += "  <DIV>"
-	buf += fmt.Sprintf("API Version: <a href=\"%s\">%s</a>\n", spec_link, h.Config.SpecVersion)
-	buf += "<A href=\"#\" class=\"btn btn-info btn-sm switch-theme\">Switch <I class=\"fa fa-sun-o\"></I>/<I class=\"fa fa-moon-o\"></I></A>\n"
-	buf += "  </DIV>\n</DIV>\n</DIV>"
+// collectIncludes reads the include files of the TOC items, in order.
+func (h *HTMLWriter) collectIncludes() []template.HTML {
 	const OK = "\033[32mOK\033[0m"
 	const NOT_FOUND = "\033[31mNot found\033[0m"
-	for _, sec := range h.TOC.Sections {
-		fmt.Printf("Collecting %s ... ", sec.File)
-		content, err := os.ReadFile(filepath.Join(api.IncludesDir, sec.File))
+
+	var result []template.HTML
+	collect := func(file, sep string) {
+		fmt.Printf("Collecting %s ...%s", file, sep)
+		content, err := os.ReadFile(filepath.Join(api.IncludesDir, file))
 		if err == nil {
-			buf += string(content)
+			result = append(result, template.HTML(content))
 			fmt.Println(OK)
 		} else {
 			fmt.Println(NOT_FOUND)
 		}
+	}
 
+	for _, sec := range h.TOC.Sections {
+		collect(sec.File, " ")
 		for _, sub := range sec.SubSections {
 			if len(sub.File) > 0 {
-				subdata, err := os.ReadFile(filepath.Join(api.IncludesDir, sub.File))
-				fmt.Printf("Collecting %s ... ", sub.File)
-				if err == nil {
-					buf += string(subdata)
-					fmt.Println(OK)
-				} else {
-					fmt.Println(NOT_FOUND)
-				}
+				collect(sub.File, " ")
 			}
-
 			for _, subsub := range sub.SubSections {
 				if len(subsub.File) > 0 {
-					subsubdata, err := os.ReadFile(filepath.Join(api.IncludesDir, subsub.File))
-					fmt.Printf("Collecting %s ...", subsub.File)
-					if err == nil {
-						buf += string(subsubdata)
-						fmt.Println(OK)
-					} else {
-						fmt.Println(NOT_FOUND)
-					}
+					collect(subsub.File, "")
 				}
 			}
 		}
 	}
+	return result
+}
+
+func (h *HTMLWriter) footer() htmlFooter {
+	pos := strings.LastIndex(h.Config.SpecVersion, ".")
+	release := fmt.Sprintf("release-%s", h.Config.SpecVersion[1:pos])
+	spec_link := "https://github.com/kubernetes/kubernetes/blob/" + release + "/api/openapi-spec/swagger.json"
+
+	return htmlFooter{
+		Copyright:   template.HTML(h.TOC.Copyright),
+		GeneratedAt: now().Format("2006-01-02 15:04:05 (MST)"),
+		SpecLink:    spec_link,
+		SpecVersion: h.Config.SpecVersion,
+	}
+}
+
+func (h *HTMLWriter) generateIndex() error {
+	data := htmlIndex{
+		htmlFooter: h.footer(),
+		Title:      h.TOC.Title,
+		Nav:        navItems(h.TOC.Sections),
+		Includes:   h.collectIncludes(),
+	}
 
-	/*
-		Make sure the following scripts exist in kubernetes/website repo:
-		kubernetes/website/static/js/jquery-3.6.0.min.js
-		kubernetes/website/static/js/jquery.scrollTo-2.1.3.min.js
-		kubernetes/website/static/js/bootstrap-5.3.2.min.js
-		kubernetes/website/static/js/apiref.js
-	*/
-	fmt.Fprintf(html, "%s</DIV>\n", navContent)
-	fmt.Fprintf(html, "<DIV id=\"page-content-wrapper\" class=\"col-xs-8 offset-xs-4 col-sm-9 offset-sm-3 col-md-10 offset-md-2 body-content\">\n")
-	fmt.Fprintf(html, "%s", string(buf))
-	fmt.Fprintf(html, "\n</DIV>\n</DIV>\n</DIV>\n")
-	fmt.Fprintf(html, "<SCRIPT src=\"/js/jquery-3.6.0.min.js\"></SCRIPT>\n")
-	fmt.Fprintf(html, "<SCRIPT src=\"/js/jquery.scrollTo-2.1.3.min.js\"></SCRIPT>\n")
-	fmt.Fprintf(html, "<SCRIPT src=\"/js/bootstrap-5.3.2.min.js\"></SCRIPT>\n")
-	fmt.Fprintf(html, "<SCRIPT src=\"/js/apiref.js\"></SCRIPT>\n")
-	fmt.Fprintf(html, "</BODY>\n</HTML>\n")
+	f, err := os.Create(filepath.Join(api.BuildDir, "index.html"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
 
+	if err := h.templates.ExecuteTemplate(f, "index", data); err != nil {
+		return fmt.Errorf("failed to render index.html: %w", err)
+	}
 	return nil
 }
 
@@ -747,9 +769,7 @@ func (h *HTMLWriter) Finalize() error {
 		return err
 	}
 
-	navContent := h.generateNavContent()
-
-	if err := h.generateIndex(navContent); err != nil {
+	if err := h.generateIndex(); err != nil {
 		return err
 	}
 
@@ -760,6 +780,6 @@ func (h *HTMLWriter) Finalize() error {
 	return nil
 }
 
-func (h *HTMLWriter) gvkMarkup(group string, version api.ApiVersion, kind string) string {
-	return fmt.Sprintf(`<span class="gvk"><span class="k">%s</span> <span class="v">%s</span> <span class="g">%s</span></span>`, kind, version, group)
+func (h *HTMLWriter) gvkMarkup(group string, version api.ApiVersion, kind string) (string, error) {
+	return h.render("gvk", htmlGVK{Group: group, Version: version, Kind: kind})
 }
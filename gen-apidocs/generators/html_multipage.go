@@ -0,0 +1,343 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+)
+
+// HTMLMultipageWriter writes the HTML reference as one page per section,
+// resource, definition and group of operations instead of a single page.
+//
+// The includes are written by the HTML writer. Finalize assembles them into
+// pages, using the TOC for the navigation shared by the pages:
+//
+//	index.html                  the first section, i.e. the overview
+//	<section>/index.html        the other sections, with links to their pages
+//	<section>/<item>.html       the resources, definitions and operation groups
+type HTMLMultipageWriter struct {
+	*HTMLWriter
+}
+
+func NewHTMLMultipageWriter(config *api.Config, copyright, title string) (DocWriter, error) {
+	writer, err := newHTMLWriter(config, copyright, title)
+	if err != nil {
+		return nil, err
+	}
+	return &HTMLMultipageWriter{HTMLWriter: writer}, nil
+}
+
+// WriteOperation groups the orphaned operations by API group and version,
+// each group getting a page.
+func (m *HTMLMultipageWriter) WriteOperation(o *api.Operation) (RenderFunc, error) {
+	section := m.currentTOCItem
+
+	group, version, _, _ := o.GetGroupVersionKindSub()
+	title := "other"
+	if group != "" {
+		title = group + "/" + version
+	}
+	link := getLink("operations " + strings.ReplaceAll(title, "/", " "))
+
+	var groupItem *TOCItem
+	for _, item := range section.SubSections {
+		if item.Link == link {
+			groupItem = item
+		}
+	}
+	if groupItem == nil {
+		groupItem = &TOCItem{
+			Level: 2,
+			Title: title,
+			Link:  link,
+		}
+		section.SubSections = append(section.SubSections, groupItem)
+	}
+
+	m.currentTOCItem = groupItem
+	defer func() { m.currentTOCItem = section }()
+	render, err := m.HTMLWriter.WriteOperation(o)
+	if err != nil {
+		return nil, err
+	}
+	groupItem.SubSections[len(groupItem.SubSections)-1].Level = 3
+	return render, nil
+}
+
+// page is a page of the multi-page reference.
+type page struct {
+	// path is the slash-separated path of the page in the build directory.
+	path    string
+	item    *TOCItem
+	section *TOCItem
+	content string
+}
+
+type htmlLink struct {
+	Title template.HTML
+	Href  string
+}
+
+type htmlPage struct {
+	htmlFooter
+	Title       string
+	Nav         []htmlNavItem
+	Breadcrumbs []htmlLink
+	Content     template.HTML
+	Children    []htmlLink
+}
+
+var (
+	idAttr     = regexp.MustCompile(`\sid="([^"]+)"`)
+	anchorHref = regexp.MustCompile(`href="#([^"]+)"`)
+	htmlTag    = regexp.MustCompile(`<[^>]*>`)
+)
+
+// relLink returns the link from the page at from to the page at to, both
+// being relative to the build directory.
+func relLink(from, to string) string {
+	return strings.Repeat("../", strings.Count(from, "/")) + to
+}
+
+// plainTitle returns the text of a TOC title, which may contain markup.
+func plainTitle(title string) string {
+	return strings.Join(strings.Fields(htmlTag.ReplaceAllString(title, " ")), " ")
+}
+
+// pages returns the pages of the TOC, with the content of their includes.
+func (m *HTMLMultipageWriter) pages() ([]*page, error) {
+	var result []*page
+	for i, sec := range m.TOC.Sections {
+		p := &page{path: path.Join(sec.Link, "index.html"), item: sec, section: sec}
+		if i == 0 {
+			p.path = "index.html"
+		}
+		result = append(result, p)
+		for _, sub := range sec.SubSections {
+			result = append(result, &page{path: path.Join(sec.Link, sub.Link+".html"), item: sub, section: sec})
+		}
+	}
+
+	for _, p := range result {
+		files := []string{p.item.File}
+		// The page of a section only shows the section include, the pages of
+		// the items show the includes of the nested items as well.
+		if p.item != p.section {
+			files = includeFiles(p.item.SubSections, files)
+		}
+		var content strings.Builder
+		for _, file := range files {
+			if file == "" {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(api.IncludesDir, file))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the content of %s: %w", p.path, err)
+			}
+			content.Write(data)
+		}
+		p.content = content.String()
+	}
+	return result, nil
+}
+
+func includeFiles(items []*TOCItem, files []string) []string {
+	for _, item := range items {
+		files = append(files, item.File)
+		files = includeFiles(item.SubSections, files)
+	}
+	return files
+}
+
+// linkPages turns the links to the anchors of other pages into links to
+// these pages.
+func linkPages(pages []*page) {
+	anchors := make(map[string]string)
+	for _, p := range pages {
+		for _, m := range idAttr.FindAllStringSubmatch(p.content, -1) {
+			if _, found := anchors[m[1]]; !found {
+				anchors[m[1]] = p.path
+			}
+		}
+	}
+
+	for _, p := range pages {
+		p.content = anchorHref.ReplaceAllStringFunc(p.content, func(href string) string {
+			anchor := anchorHref.FindStringSubmatch(href)[1]
+			target, found := anchors[anchor]
+			if !found || target == p.path {
+				return href
+			}
+			return fmt.Sprintf(`href="%s#%s"`, relLink(p.path, target), anchor)
+		})
+	}
+}
+
+// nav returns the navigation of a page: the sections, the pages of the
+// section of the page and the headings of the page.
+func (m *HTMLMultipageWriter) nav(p *page, paths map[*TOCItem]string) []htmlNavItem {
+	var result []htmlNavItem
+	for _, sec := range m.TOC.Sections {
+		item := htmlNavItem{
+			Level:   sec.Level,
+			Title:   template.HTML(sec.Title),
+			Link:    sec.Link,
+			Href:    relLink(p.path, paths[sec]),
+			Current: sec == p.item,
+		}
+		if sec == p.section {
+			for _, sub := range sec.SubSections {
+				subItem := htmlNavItem{
+					Level:   sub.Level,
+					Title:   template.HTML(sub.Title),
+					Link:    sub.Link,
+					Href:    relLink(p.path, paths[sub]),
+					Current: sub == p.item,
+				}
+				if sub == p.item {
+					subItem.SubSections = headingItems(sub.SubSections)
+				}
+				item.SubSections = append(item.SubSections, subItem)
+			}
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+// headingItems returns the navigation of the headings of the current page.
+func headingItems(items []*TOCItem) []htmlNavItem {
+	var result []htmlNavItem
+	for _, item := range items {
+		result = append(result, htmlNavItem{
+			Level:       item.Level,
+			Title:       template.HTML(item.Title),
+			Link:        item.Link,
+			Href:        "#" + item.Link,
+			SubSections: headingItems(item.SubSections),
+		})
+	}
+	return result
+}
+
+func (m *HTMLMultipageWriter) breadcrumbs(p *page, pages []*page, paths map[*TOCItem]string) []htmlLink {
+	home := pages[0]
+	if p == home {
+		return nil
+	}
+	crumbs := []htmlLink{{Title: template.HTML(template.HTMLEscapeString(m.TOC.Title)), Href: relLink(p.path, home.path)}}
+	if p.section != home.section {
+		crumbs = append(crumbs, htmlLink{Title: template.HTML(p.section.Title), Href: relLink(p.path, paths[p.section])})
+	}
+	if p.item != p.section {
+		crumbs = append(crumbs, htmlLink{Title: template.HTML(p.item.Title)})
+	} else {
+		crumbs[len(crumbs)-1].Href = ""
+	}
+	return crumbs
+}
+
+type sitemapURL struct {
+	Loc string `xml:"loc"`
+}
+
+type sitemap struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+func (m *HTMLMultipageWriter) writeSitemap(pages []*page) error {
+	base := *api.SiteURL
+	if base == "" {
+		base = fmt.Sprintf("https://kubernetes.io/docs/reference/generated/kubernetes-api/v%s/", *api.KubernetesRelease)
+	}
+	if !strings.HasSuffix(base, "/") {
+		base += "/"
+	}
+
+	s := sitemap{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	for _, p := range pages {
+		loc := base + p.path
+		if p.path == "index.html" {
+			loc = base
+		}
+		s.URLs = append(s.URLs, sitemapURL{Loc: loc})
+	}
+
+	data, err := xml.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	content := xml.Header + string(data) + "\n"
+	return os.WriteFile(filepath.Join(api.BuildDir, "sitemap.xml"), []byte(content), 0644)
+}
+
+func (m *HTMLMultipageWriter) Finalize() error {
+	pages, err := m.pages()
+	if err != nil {
+		return err
+	}
+	linkPages(pages)
+
+	paths := make(map[*TOCItem]string)
+	for _, p := range pages {
+		paths[p.item] = p.path
+	}
+
+	footer := m.footer()
+	for _, p := range pages {
+		data := htmlPage{
+			htmlFooter:  footer,
+			Title:       m.TOC.Title,
+			Nav:         m.nav(p, paths),
+			Breadcrumbs: m.breadcrumbs(p, pages, paths),
+			Content:     template.HTML(p.content),
+		}
+		if p != pages[0] {
+			data.Title = plainTitle(p.item.Title) + " - " + m.TOC.Title
+		}
+		if p.item == p.section {
+			for _, sub := range p.section.SubSections {
+				data.Children = append(data.Children, htmlLink{Title: template.HTML(sub.Title), Href: relLink(p.path, paths[sub])})
+			}
+		}
+
+		content, err := m.render("page", data)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", p.path, err)
+		}
+		dst := filepath.Join(api.BuildDir, filepath.FromSlash(p.path))
+		if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+
+	return m.writeSitemap(pages)
+}
@@ -28,6 +28,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/kubernetes-sigs/reference-docs/diagnostics"
 	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
 )
 
@@ -38,6 +39,10 @@ import (
 //	<category-slug>/<resource>-<ver>.md    — per-resource page
 //	definitions/<name>-<ver>-<group>.md    — standalone definitions
 //	operations/<op-id>.md                  — orphaned operations
+//
+// The weights of the pages and the TOC are computed when the pages are added,
+// in the order of the reference, so that the pages can be rendered in any
+// order.
 type MarkdownWriter struct {
 	Config          *api.Config
 	OutputDir       string
@@ -56,9 +61,6 @@ type MarkdownWriter struct {
 	inlinedByParent map[string][]*api.Definition
 
 	toc []*mdTOCItem
-
-	// finalized guards against Finalize being called twice by GenerateFiles.
-	finalized bool
 }
 
 type mdCategory struct {
@@ -186,7 +188,7 @@ func hugoRef(path string) string {
 func NewMarkdownWriter(config *api.Config, copyright, title string) DocWriter {
 	outputDir := api.BuildDir
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		fmt.Fprintf(os.Stderr, "MarkdownWriter: failed to create output dir %s: %v\n", outputDir, err)
+		api.Diagnostics.Errorf("output-dir-failed", diagnostics.Location{}, "MarkdownWriter: failed to create output dir %s: %v", outputDir, err)
 	}
 	m := &MarkdownWriter{
 		Config:    config,
@@ -290,26 +292,28 @@ func (m *MarkdownWriter) WriteResourceCategory(name, file string) error {
 	return nil
 }
 
-func (m *MarkdownWriter) WriteResource(r *api.Resource) error {
+func (m *MarkdownWriter) WriteResource(r *api.Resource) (RenderFunc, error) {
 	slug := m.currentCategory.slug
 	if r.Definition != nil && r.Definition.IsOldVersion {
-		return nil // markdown backend omits old-version pages; current version is canonical
+		return nil, nil // markdown backend omits old-version pages; current version is canonical
 	}
 
 	filename := fmt.Sprintf("%s-%s.md", kebabName(r.Name), r.Definition.Version)
 	path := filepath.Join(m.OutputDir, slug, filename)
+	weight := m.nextResourceWeight()
 
-	f, err := os.Create(path)
-	if err != nil {
-		return fmt.Errorf("markdown: resource %s: %w", r.Name, err)
-	}
-	defer f.Close()
-
-	if err := resourceTemplate.Execute(f, m.buildResourcePage(r, slug)); err != nil {
-		return fmt.Errorf("markdown: resource %s body: %w", r.Name, err)
-	}
+	return func() error {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("markdown: resource %s: %w", r.Name, err)
+		}
+		defer f.Close()
 
-	return nil
+		if err := resourceTemplate.Execute(f, m.buildResourcePage(r, slug, weight)); err != nil {
+			return fmt.Errorf("markdown: resource %s body: %w", r.Name, err)
+		}
+		return nil
+	}, nil
 }
 
 // definitions/_index.md is required for Hugo to nest definition pages
@@ -336,9 +340,9 @@ func (m *MarkdownWriter) WriteDefinitionsOverview() error {
 	return nil
 }
 
-func (m *MarkdownWriter) WriteDefinition(d *api.Definition) error {
+func (m *MarkdownWriter) WriteDefinition(d *api.Definition) (RenderFunc, error) {
 	if m.classifications[d.Key()].Mode == classifyInline {
-		return nil
+		return nil, nil
 	}
 	filename := kebabName(d.Name) + "-" + string(d.Version)
 	if d.Group != "" && d.Group != "core" {
@@ -346,17 +350,20 @@ func (m *MarkdownWriter) WriteDefinition(d *api.Definition) error {
 	}
 	filename += ".md"
 	path := filepath.Join(m.OutputDir, "definitions", filename)
+	weight := m.nextResourceWeight()
 
-	f, err := os.Create(path)
-	if err != nil {
-		return fmt.Errorf("markdown: definition %s: %w", d.Name, err)
-	}
-	defer f.Close()
+	return func() error {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("markdown: definition %s: %w", d.Name, err)
+		}
+		defer f.Close()
 
-	if err := resourceTemplate.Execute(f, m.buildDefinitionPage(d, "definitions")); err != nil {
-		return fmt.Errorf("markdown: definition %s body: %w", d.Name, err)
-	}
-	return nil
+		if err := resourceTemplate.Execute(f, m.buildDefinitionPage(d, "definitions", weight)); err != nil {
+			return fmt.Errorf("markdown: definition %s body: %w", d.Name, err)
+		}
+		return nil
+	}, nil
 }
 
 func (m *MarkdownWriter) WriteOrphanedOperationsOverview() error {
@@ -381,21 +388,24 @@ func (m *MarkdownWriter) WriteOrphanedOperationsOverview() error {
 	return nil
 }
 
-func (m *MarkdownWriter) WriteOperation(o *api.Operation) error {
+func (m *MarkdownWriter) WriteOperation(o *api.Operation) (RenderFunc, error) {
 	filename := operationSlug(o.ID) + ".md"
 	path := filepath.Join(m.OutputDir, "operations", filename)
+	weight := m.nextResourceWeight()
 
-	f, err := os.Create(path)
-	if err != nil {
-		return fmt.Errorf("markdown: operation %s: %w", o.ID, err)
-	}
-	defer f.Close()
+	return func() error {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("markdown: operation %s: %w", o.ID, err)
+		}
+		defer f.Close()
 
-	writeSectionFrontmatter(f, o.Type.Name, o.Description(), m.nextResourceWeight())
-	if err := resourceTemplate.ExecuteTemplate(f, "operation", m.buildTemplateOperation(o, "operations")); err != nil {
-		return fmt.Errorf("markdown: operation %s body: %w", o.ID, err)
-	}
-	return nil
+		writeSectionFrontmatter(f, o.Type.Name, o.Description(), weight)
+		if err := resourceTemplate.ExecuteTemplate(f, "operation", m.buildTemplateOperation(o, "operations")); err != nil {
+			return fmt.Errorf("markdown: operation %s body: %w", o.ID, err)
+		}
+		return nil
+	}, nil
 }
 
 // No-op: old versions render as resource pages routed to their group folder.
@@ -404,11 +414,6 @@ func (m *MarkdownWriter) WriteOldVersionsOverview() error {
 }
 
 func (m *MarkdownWriter) Finalize() error {
-	if m.finalized {
-		return nil
-	}
-	m.finalized = true
-
 	path := filepath.Join(m.OutputDir, hugoIndex)
 	f, err := os.Create(path)
 	if err != nil {
@@ -424,8 +429,8 @@ func (m *MarkdownWriter) Finalize() error {
 	fmt.Fprintf(f, "# %s\n\n", m.Config.SpecTitle)
 	fmt.Fprintf(f, "_Version: %s_\n\n", m.Config.SpecVersion)
 
-	// Sort because --auto-detect populates categories via map iteration,
-	// which is non-deterministic. tocSortRank pins header pages.
+	// tocSortRank pins header pages; the categories are listed
+	// alphabetically between them.
 	sort.SliceStable(m.toc, func(i, j int) bool {
 		ri, rj := tocSortRank(m.toc[i].title), tocSortRank(m.toc[j].title)
 		if ri != rj {
@@ -440,8 +445,8 @@ func (m *MarkdownWriter) Finalize() error {
 	return nil
 }
 
-func (m *MarkdownWriter) buildResourcePage(r *api.Resource, currentCategory string) resourcePage {
-	page := m.buildDefinitionPage(r.Definition, currentCategory)
+func (m *MarkdownWriter) buildResourcePage(r *api.Resource, currentCategory string, weight int) resourcePage {
+	page := m.buildDefinitionPage(r.Definition, currentCategory, weight)
 	for _, oc := range r.Definition.OperationCategories {
 		for _, o := range oc.Operations {
 			page.Operations = append(page.Operations, m.buildTemplateOperation(o, currentCategory))
@@ -452,13 +457,13 @@ func (m *MarkdownWriter) buildResourcePage(r *api.Resource, currentCategory stri
 
 // One H2 section per top-level inlined child (Spec, Status, List); deeper
 // inlines stay flattened with dot-notation in their containing section.
-func (m *MarkdownWriter) buildDefinitionPage(d *api.Definition, currentCategory string) resourcePage {
+func (m *MarkdownWriter) buildDefinitionPage(d *api.Definition, currentCategory string, weight int) resourcePage {
 	page := resourcePage{
 		APIVersion:  groupVersionString(d.GroupFullName, d.Version),
 		Kind:        d.Name,
 		Import:      d.GoImportPath(),
 		Title:       d.Name,
-		Weight:      m.nextResourceWeight(),
+		Weight:      weight,
 		Anchor:      anchor(d.Name),
 		Description: d.DescriptionWithEntities,
 	}
@@ -614,7 +619,7 @@ func (m *MarkdownWriter) buildTemplateOperation(o *api.Operation, currentCategor
 // On collisions: resource entries beat definition entries; higher version wins.
 func (m *MarkdownWriter) buildLinkMap(config *api.Config) {
 	m.linkResources(config.ResourceCategories)
-	m.linkDefinitions(config.Definitions.All)
+	m.linkDefinitions(config.Definitions.Sorted())
 }
 
 type classifyMode int
@@ -634,7 +639,7 @@ type defClassification struct {
 func (m *MarkdownWriter) classifyDefinitions() map[string]defClassification {
 	out := make(map[string]defClassification, len(m.Config.Definitions.All))
 	m.inlinedByParent = map[string][]*api.Definition{}
-	for _, d := range m.Config.Definitions.All {
+	for _, d := range m.Config.Definitions.Sorted() {
 		if d.IsOldVersion || d.IsInlined || d.InToc {
 			out[d.Key()] = defClassification{Mode: classifySkip}
 			continue
@@ -720,7 +725,7 @@ func (m *MarkdownWriter) linkResources(categories []api.ResourceCategory) {
 	}
 }
 
-func (m *MarkdownWriter) linkDefinitions(all map[string]*api.Definition) {
+func (m *MarkdownWriter) linkDefinitions(all []*api.Definition) {
 	for _, d := range all {
 		if d.InToc || d.IsInlined || d.IsOldVersion {
 			continue
@@ -140,9 +140,13 @@ func TestWriteResourceGolden(t *testing.T) {
 	m.currentCategory = mdCategory{name: testCategoryName, slug: testCategorySlug}
 
 	r := fabricateDeploymentResource()
-	if err := m.WriteResource(r); err != nil {
+	render, err := m.WriteResource(r)
+	if err != nil {
 		t.Fatalf("WriteResource: %v", err)
 	}
+	if err := render(); err != nil {
+		t.Fatalf("render resource: %v", err)
+	}
 
 	compareWithGolden(t,
 		filepath.Join(m.OutputDir, testCategorySlug, "deployment-v1.md"),
@@ -158,9 +162,13 @@ func TestWriteOperationGolden(t *testing.T) {
 	}
 
 	o := fabricateOperation()
-	if err := m.WriteOperation(o); err != nil {
+	render, err := m.WriteOperation(o)
+	if err != nil {
 		t.Fatalf("WriteOperation: %v", err)
 	}
+	if err := render(); err != nil {
+		t.Fatalf("render operation: %v", err)
+	}
 
 	compareWithGolden(t,
 		filepath.Join(m.OutputDir, "operations", "listcorev1pod.md"),
@@ -177,9 +185,13 @@ func TestWriteOperationGoldenHugoMode(t *testing.T) {
 	}
 
 	o := fabricateOperation()
-	if err := m.WriteOperation(o); err != nil {
+	render, err := m.WriteOperation(o)
+	if err != nil {
 		t.Fatalf("WriteOperation: %v", err)
 	}
+	if err := render(); err != nil {
+		t.Fatalf("render operation: %v", err)
+	}
 
 	compareWithGolden(t,
 		filepath.Join(m.OutputDir, "operations", "listcorev1pod.md"),
@@ -196,13 +208,9 @@ func TestResolveType(t *testing.T) {
 			},
 		},
 	})
-	m.linkDefinitions(map[string]*api.Definition{
-		"io.k8s.api.apps.v1.DeploymentSpec": {
-			Name: "DeploymentSpec", Group: api.ApiGroup("apps"), Version: api.ApiVersion("v1"),
-		},
-		"io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta": {
-			Name: "ObjectMeta", Group: api.ApiGroup("meta"), Version: api.ApiVersion("v1"),
-		},
+	m.linkDefinitions([]*api.Definition{
+		{Name: "DeploymentSpec", Group: api.ApiGroup("apps"), Version: api.ApiVersion("v1")},
+		{Name: "ObjectMeta", Group: api.ApiGroup("meta"), Version: api.ApiVersion("v1")},
 	})
 
 	cases := []struct {
@@ -410,10 +418,7 @@ func TestLinkDefinitionsSkipsInlined(t *testing.T) {
 		},
 	}
 
-	m.linkDefinitions(map[string]*api.Definition{
-		azureDisk.Key():  azureDisk,
-		objectMeta.Key(): objectMeta,
-	})
+	m.linkDefinitions([]*api.Definition{azureDisk, objectMeta})
 
 	if _, exists := m.linkMap["AzureDiskVolumeSource"]; exists {
 		t.Error("inlined AzureDiskVolumeSource got a definitions/ entry; should have been skipped")
@@ -0,0 +1,480 @@
+window.navData = [
+  {
+    "Level": 1,
+    "Title": "Overview",
+    "Link": "api-overview",
+    "File": "_overview.html",
+    "SubSections": null
+  },
+  {
+    "Level": 1,
+    "Title": "API Groups",
+    "Link": "api-groups",
+    "File": "_group_versions.html",
+    "SubSections": null
+  },
+  {
+    "Level": 1,
+    "Title": "Config and Storage APIs",
+    "Link": "config-and-storage-apis",
+    "File": "_config.html",
+    "SubSections": [
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eConfigMap\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003ecore\u003c/span\u003e\u003c/span\u003e",
+        "Link": "configmap-v1-core",
+        "File": "_generated_configmap_v1_core_concept.html",
+        "SubSections": [
+          {
+            "Level": 3,
+            "Title": "Write Operations",
+            "Link": "write-operations-configmap-v1-core",
+            "File": "",
+            "SubSections": [
+              {
+                "Level": 4,
+                "Title": "Create",
+                "Link": "create-configmap-v1-core",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Patch",
+                "Link": "patch-configmap-v1-core",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Replace",
+                "Link": "replace-configmap-v1-core",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Delete",
+                "Link": "delete-configmap-v1-core",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Delete Collection",
+                "Link": "delete-collection-configmap-v1-core",
+                "File": "",
+                "SubSections": null
+              }
+            ]
+          },
+          {
+            "Level": 3,
+            "Title": "Read Operations",
+            "Link": "read-operations-configmap-v1-core",
+            "File": "",
+            "SubSections": [
+              {
+                "Level": 4,
+                "Title": "Read",
+                "Link": "read-configmap-v1-core",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "List",
+                "Link": "list-configmap-v1-core",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "List All Namespaces",
+                "Link": "list-all-namespaces-configmap-v1-core",
+                "File": "",
+                "SubSections": null
+              }
+            ]
+          }
+        ]
+      }
+    ]
+  },
+  {
+    "Level": 1,
+    "Title": "Cluster APIs",
+    "Link": "cluster-apis",
+    "File": "_cluster.html",
+    "SubSections": [
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eLease\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003ecoordination\u003c/span\u003e\u003c/span\u003e",
+        "Link": "lease-v1-coordination",
+        "File": "_generated_lease_v1_coordination_concept.html",
+        "SubSections": [
+          {
+            "Level": 3,
+            "Title": "Write Operations",
+            "Link": "write-operations-lease-v1-coordination",
+            "File": "",
+            "SubSections": [
+              {
+                "Level": 4,
+                "Title": "Create",
+                "Link": "create-lease-v1-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Patch",
+                "Link": "patch-lease-v1-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Replace",
+                "Link": "replace-lease-v1-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Delete",
+                "Link": "delete-lease-v1-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Delete Collection",
+                "Link": "delete-collection-lease-v1-coordination",
+                "File": "",
+                "SubSections": null
+              }
+            ]
+          },
+          {
+            "Level": 3,
+            "Title": "Read Operations",
+            "Link": "read-operations-lease-v1-coordination",
+            "File": "",
+            "SubSections": [
+              {
+                "Level": 4,
+                "Title": "Read",
+                "Link": "read-lease-v1-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "List",
+                "Link": "list-lease-v1-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "List All Namespaces",
+                "Link": "list-all-namespaces-lease-v1-coordination",
+                "File": "",
+                "SubSections": null
+              }
+            ]
+          }
+        ]
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eLeaseCandidate\u003c/span\u003e \u003cspan class=\"v\"\u003ev1beta1\u003c/span\u003e \u003cspan class=\"g\"\u003ecoordination\u003c/span\u003e\u003c/span\u003e",
+        "Link": "leasecandidate-v1beta1-coordination",
+        "File": "_generated_leasecandidate_v1beta1_coordination_concept.html",
+        "SubSections": [
+          {
+            "Level": 3,
+            "Title": "Write Operations",
+            "Link": "write-operations-leasecandidate-v1beta1-coordination",
+            "File": "",
+            "SubSections": [
+              {
+                "Level": 4,
+                "Title": "Create",
+                "Link": "create-leasecandidate-v1beta1-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Patch",
+                "Link": "patch-leasecandidate-v1beta1-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Replace",
+                "Link": "replace-leasecandidate-v1beta1-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Delete",
+                "Link": "delete-leasecandidate-v1beta1-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Delete Collection",
+                "Link": "delete-collection-leasecandidate-v1beta1-coordination",
+                "File": "",
+                "SubSections": null
+              }
+            ]
+          },
+          {
+            "Level": 3,
+            "Title": "Read Operations",
+            "Link": "read-operations-leasecandidate-v1beta1-coordination",
+            "File": "",
+            "SubSections": [
+              {
+                "Level": 4,
+                "Title": "Read",
+                "Link": "read-leasecandidate-v1beta1-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "List",
+                "Link": "list-leasecandidate-v1beta1-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "List All Namespaces",
+                "Link": "list-all-namespaces-leasecandidate-v1beta1-coordination",
+                "File": "",
+                "SubSections": null
+              }
+            ]
+          }
+        ]
+      }
+    ]
+  },
+  {
+    "Level": 1,
+    "Title": "DEFINITIONS",
+    "Link": "definitions",
+    "File": "_definitions.html",
+    "SubSections": [
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eAPIGroup\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "apigroup-v1-meta",
+        "File": "_generated_apigroup_v1_meta_definition.html",
+        "SubSections": null
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eDeleteOptions\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "deleteoptions-v1-meta",
+        "File": "_generated_deleteoptions_v1_meta_definition.html",
+        "SubSections": null
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eFieldsV1\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "fieldsv1-v1-meta",
+        "File": "_generated_fieldsv1_v1_meta_definition.html",
+        "SubSections": null
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eGroupVersionForDiscovery\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "groupversionfordiscovery-v1-meta",
+        "File": "_generated_groupversionfordiscovery_v1_meta_definition.html",
+        "SubSections": null
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eListMeta\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "listmeta-v1-meta",
+        "File": "_generated_listmeta_v1_meta_definition.html",
+        "SubSections": null
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eManagedFieldsEntry\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "managedfieldsentry-v1-meta",
+        "File": "_generated_managedfieldsentry_v1_meta_definition.html",
+        "SubSections": null
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eMicroTime\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "microtime-v1-meta",
+        "File": "_generated_microtime_v1_meta_definition.html",
+        "SubSections": null
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eObjectMeta\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "objectmeta-v1-meta",
+        "File": "_generated_objectmeta_v1_meta_definition.html",
+        "SubSections": null
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eOwnerReference\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "ownerreference-v1-meta",
+        "File": "_generated_ownerreference_v1_meta_definition.html",
+        "SubSections": null
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003ePatch\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "patch-v1-meta",
+        "File": "_generated_patch_v1_meta_definition.html",
+        "SubSections": null
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003ePreconditions\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "preconditions-v1-meta",
+        "File": "_generated_preconditions_v1_meta_definition.html",
+        "SubSections": null
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eServerAddressByClientCIDR\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "serveraddressbyclientcidr-v1-meta",
+        "File": "_generated_serveraddressbyclientcidr_v1_meta_definition.html",
+        "SubSections": null
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eStatus\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "status-v1-meta",
+        "File": "_generated_status_v1_meta_definition.html",
+        "SubSections": null
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eStatusCause\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "statuscause-v1-meta",
+        "File": "_generated_statuscause_v1_meta_definition.html",
+        "SubSections": null
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eStatusDetails\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "statusdetails-v1-meta",
+        "File": "_generated_statusdetails_v1_meta_definition.html",
+        "SubSections": null
+      },
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eTime\u003c/span\u003e \u003cspan class=\"v\"\u003ev1\u003c/span\u003e \u003cspan class=\"g\"\u003emeta\u003c/span\u003e\u003c/span\u003e",
+        "Link": "time-v1-meta",
+        "File": "_generated_time_v1_meta_definition.html",
+        "SubSections": null
+      }
+    ]
+  },
+  {
+    "Level": 1,
+    "Title": "OLD API VERSIONS",
+    "Link": "old-api-versions",
+    "File": "_oldversions.html",
+    "SubSections": [
+      {
+        "Level": 2,
+        "Title": "\u003cspan class=\"gvk\"\u003e\u003cspan class=\"k\"\u003eLeaseCandidate\u003c/span\u003e \u003cspan class=\"v\"\u003ev1alpha2\u003c/span\u003e \u003cspan class=\"g\"\u003ecoordination\u003c/span\u003e\u003c/span\u003e",
+        "Link": "leasecandidate-v1alpha2-coordination",
+        "File": "_generated_leasecandidate_v1alpha2_coordination_concept.html",
+        "SubSections": [
+          {
+            "Level": 3,
+            "Title": "Write Operations",
+            "Link": "write-operations-leasecandidate-v1alpha2-coordination",
+            "File": "",
+            "SubSections": [
+              {
+                "Level": 4,
+                "Title": "Create",
+                "Link": "create-leasecandidate-v1alpha2-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Patch",
+                "Link": "patch-leasecandidate-v1alpha2-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Replace",
+                "Link": "replace-leasecandidate-v1alpha2-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Delete",
+                "Link": "delete-leasecandidate-v1alpha2-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "Delete Collection",
+                "Link": "delete-collection-leasecandidate-v1alpha2-coordination",
+                "File": "",
+                "SubSections": null
+              }
+            ]
+          },
+          {
+            "Level": 3,
+            "Title": "Read Operations",
+            "Link": "read-operations-leasecandidate-v1alpha2-coordination",
+            "File": "",
+            "SubSections": [
+              {
+                "Level": 4,
+                "Title": "Read",
+                "Link": "read-leasecandidate-v1alpha2-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "List",
+                "Link": "list-leasecandidate-v1alpha2-coordination",
+                "File": "",
+                "SubSections": null
+              },
+              {
+                "Level": 4,
+                "Title": "List All Namespaces",
+                "Link": "list-all-namespaces-leasecandidate-v1alpha2-coordination",
+                "File": "",
+                "SubSections": null
+              }
+            ]
+          }
+        ]
+      }
+    ]
+  }
+];
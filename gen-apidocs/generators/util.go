@@ -17,10 +17,10 @@ limitations under the License.
 package generators
 
 import (
-	"fmt"
-
+	"sort"
 	"strings"
 
+	"github.com/kubernetes-sigs/reference-docs/diagnostics"
 	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
 )
 
@@ -68,27 +68,15 @@ func PrintInfo(config *api.Config) {
 		}
 	}
 
-	// print orphaned results
-	if len(orphaned) > 0 {
-		fmt.Println("----------------------------------")
-		fmt.Println("Orphaned Definitions:")
-		for _, name := range orphaned {
-			fmt.Printf("[%s]\n", name)
-		}
-		if !*api.AllowErrors {
-			fmt.Println("Possible orphaned definitions found.")
-		}
+	// report orphaned results
+	sort.Strings(orphaned)
+	for _, name := range orphaned {
+		api.Diagnostics.Warningf("orphaned-definition", diagnostics.Location{Definition: name}, "definition is not referenced by any field or operation")
 	}
 
-	// print missing TOC results
-	if len(missingToc) > 0 {
-		fmt.Println("----------------------------------")
-		fmt.Println("Definitions with Operations Missing from Toc (Excluding old version):")
-		for _, item := range missingToc {
-			fmt.Printf("[%s]\n", item.Name)
-			for _, op := range item.Operations {
-				fmt.Printf("\t [%s]\n", op)
-			}
-		}
+	// report missing TOC results
+	sort.Slice(missingToc, func(i, j int) bool { return missingToc[i].Name < missingToc[j].Name })
+	for _, item := range missingToc {
+		api.Diagnostics.Warningf("operations-not-in-toc", diagnostics.Location{Definition: item.Name}, "definition with operations missing from the TOC: %s", strings.Join(item.Operations, ", "))
 	}
 }
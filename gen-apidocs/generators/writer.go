@@ -21,32 +21,108 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/kubernetes-sigs/reference-docs/diagnostics"
 	"github.com/kubernetes-sigs/reference-docs/gen-apidocs/generators/api"
+	"github.com/kubernetes-sigs/reference-docs/manifest"
 )
 
 type Doc struct {
 	Filename string `json:"filename,omitempty"`
 }
 
+// RenderFunc renders a page of the reference, i.e. a resource, definition or
+// operation.
+type RenderFunc func() error
+
+// DocWriter writes the reference in the format of a backend.
+//
+// The methods are called sequentially, in the order of the reference.
+// WriteResource, WriteDefinition and WriteOperation only add their page to
+// the structure of the reference, e.g. the TOC or the weights of the pages,
+// and return the function rendering it, or nil if the backend has no page
+// for it. The render functions are called concurrently before Finalize, so
+// they must not change the state of the writer.
 type DocWriter interface {
 	Extension() string
 	DefaultStaticContent(title string) string
 	WriteOverview() error
 	WriteAPIGroupVersions(gvs api.GroupVersions) error
 	WriteResourceCategory(name, file string) error
-	WriteResource(r *api.Resource) error
+	WriteResource(r *api.Resource) (RenderFunc, error)
 	WriteDefinitionsOverview() error
 	WriteOrphanedOperationsOverview() error
-	WriteDefinition(d *api.Definition) error
-	WriteOperation(o *api.Operation) error
+	WriteDefinition(d *api.Definition) (RenderFunc, error)
+	WriteOperation(o *api.Operation) (RenderFunc, error)
 	WriteOldVersionsOverview() error
 	Finalize() error
 }
 
+// pageQueue collects the pages of the reference, in order, until they are
+// rendered.
+type pageQueue []queuedPage
+
+type queuedPage struct {
+	// name describes the page in errors, e.g. "resource 'Pod'".
+	name   string
+	render RenderFunc
+}
+
+func (q *pageQueue) add(name string, render RenderFunc) {
+	if render != nil {
+		*q = append(*q, queuedPage{name: name, render: render})
+	}
+}
+
+// render renders the pages on a pool of workers. The pages do not depend on
+// each other, so the output is the same whatever the number of workers, and
+// the error returned is the one of the first page failing in the order of
+// the reference.
+func (q pageQueue) render(workers int) error {
+	workers = max(1, min(workers, len(q)))
+	errs := make([]error, len(q))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				errs[i] = q[i].render()
+			}
+		}()
+	}
+	for i := range q {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", q[i].name, err)
+		}
+	}
+	return nil
+}
+
+// now returns the time dating the generated files: the time set in
+// SOURCE_DATE_EPOCH, so that a rerun does not change the files, or else the
+// current time. Tests replace it to get reproducible output.
+var now = func() time.Time {
+	if epoch, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64); err == nil {
+		return time.Unix(epoch, 0).UTC()
+	}
+	return time.Now()
+}
+
 func GenerateFiles() error {
+	api.Diagnostics = diagnostics.New(os.Stderr)
+
 	// load the yaml config
 	config, err := api.NewConfig()
 	if err != nil {
@@ -56,6 +132,25 @@ func GenerateFiles() error {
 
 	PrintInfo(config)
 
+	// The files are written into a staging directory, then only the changed
+	// ones replace the files of the previous run in the build directory.
+	buildDir, includesDir := api.BuildDir, api.IncludesDir
+	defer func() { api.BuildDir, api.IncludesDir = buildDir, includesDir }()
+	stats, err := manifest.Generate(buildDir, "gen-apidocs", func(staging string) error {
+		api.BuildDir = staging
+		api.IncludesDir = filepath.Join(staging, filepath.Base(includesDir))
+		return writeFiles(config)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Updated %s: %s\n", buildDir, stats)
+
+	return checkDiagnostics()
+}
+
+// writeFiles writes all the files of the backend into api.BuildDir.
+func writeFiles(config *api.Config) error {
 	if err := ensureDirectories(); err != nil {
 
 		return fmt.Errorf("failed to ensure directories: %w", err)
@@ -64,10 +159,23 @@ func GenerateFiles() error {
 	copyright, title := getCopyrightAndTitle()
 
 	var writer DocWriter
+	var err error
 	switch *api.Backend {
 	case "html":
 		fmt.Println("Using HTML backend for documentation generation.")
-		writer = NewHTMLWriter(config, copyright, title)
+		writer, err = NewHTMLWriter(config, copyright, title)
+		if err != nil {
+			return err
+		}
+	case "html-multipage":
+		fmt.Println("Using multi-page HTML backend for documentation generation.")
+		writer, err = NewHTMLMultipageWriter(config, copyright, title)
+		if err != nil {
+			return err
+		}
+	case "asciidoc":
+		fmt.Println("Using AsciiDoc backend for documentation generation.")
+		writer = NewAsciiDocWriter(config, copyright, title)
 	case "markdown":
 		fmt.Println("Using Markdown backend for documentation generation.")
 		writer = NewMarkdownWriter(config, copyright, title)
@@ -75,7 +183,7 @@ func GenerateFiles() error {
 		fmt.Println("Using Hugo-flavored Markdown backend for documentation generation.")
 		writer = NewHugoMDWriter(config, copyright, title)
 	default:
-		return fmt.Errorf("unsupported backend '%s': must be 'html', 'markdown', or 'hugo-md'", *api.Backend)
+		return fmt.Errorf("unsupported backend '%s': must be 'html', 'html-multipage', 'asciidoc', 'markdown', or 'hugo-md'", *api.Backend)
 	}
 
 	// Write the main overview page directly to avoid an unnecessary thin wrapper
@@ -86,16 +194,20 @@ func GenerateFiles() error {
 	if err := writer.WriteAPIGroupVersions(config.Definitions.GroupVersions); err != nil {
 		return fmt.Errorf("failed to write API group versions: %w", err)
 	}
-	if err := writeResourceCategories(writer, config); err != nil {
+	var pages pageQueue
+	if err := writeResourceCategories(writer, config, &pages); err != nil {
+		return err
+	}
+	if err := writeOrphanedOperations(writer, config, &pages); err != nil {
 		return err
 	}
-	if err := writeOrphanedOperations(writer, config); err != nil {
+	if err := writeDefinitions(writer, config, &pages); err != nil {
 		return err
 	}
-	if err := writeDefinitions(writer, config); err != nil {
+	if err := writeOldVersions(writer, config, &pages); err != nil {
 		return err
 	}
-	if err := writeOldVersions(writer, config); err != nil {
+	if err := pages.render(*api.Workers); err != nil {
 		return err
 	}
 
@@ -105,10 +217,24 @@ func GenerateFiles() error {
 	return nil
 }
 
+// checkDiagnostics writes the diagnostics of the run to --diagnostics-file
+// and returns an error if errors were reported, unless --allow-errors is
+// set, or if warnings were reported with --strict.
+func checkDiagnostics() error {
+	if *api.DiagnosticsFile != "" {
+		if err := api.Diagnostics.WriteReport(*api.DiagnosticsFile); err != nil {
+			return fmt.Errorf("failed to write diagnostics: %w", err)
+		}
+	}
+	if *api.AllowErrors && !*api.Strict {
+		return nil
+	}
+	return api.Diagnostics.Err(*api.Strict)
+}
+
 func getCopyrightAndTitle() (string, string) {
 	copyright_tmpl := "<a href=\"https://github.com/kubernetes/kubernetes\">Copyright 2016-%s The Kubernetes Authors.</a>"
-	now := time.Now().Format("2006")
-	copyright := fmt.Sprintf(copyright_tmpl, now)
+	copyright := fmt.Sprintf(copyright_tmpl, now().Format("2006"))
 	var title string
 	if !*api.BuildOps {
 		title = "Kubernetes Resource Reference Docs"
@@ -123,7 +249,7 @@ func getCopyrightAndTitle() (string, string) {
 // indirection while keeping the rest of the refactor.)
 
 // writeResourceCategories processes and writes all resource categories and their resources
-func writeResourceCategories(writer DocWriter, config *api.Config) error {
+func writeResourceCategories(writer DocWriter, config *api.Config, pages *pageQueue) error {
 	// Iterate through each configured resource category
 	for _, c := range config.ResourceCategories {
 		// Write the category overview page
@@ -134,23 +260,24 @@ func writeResourceCategories(writer DocWriter, config *api.Config) error {
 
 		// Process each resource within the category
 		for _, r := range c.Resources {
-			// Skip resources without definitions and warn about missing data
+			// Skip resources without definitions, reported as missing-definition
+			// errors when loading the config
 			if r.Definition == nil {
-
-				fmt.Printf("Warning: Missing definition for item in TOC %s\n", r.Name)
 				continue
 			}
-			// Write individual resource documentation
-			if err := writer.WriteResource(r); err != nil {
+			// Queue individual resource documentation
+			render, err := writer.WriteResource(r)
+			if err != nil {
 				// Include resource name in error for easier debugging
 				return fmt.Errorf("failed to write resource '%s': %w", r.Name, err)
 			}
+			pages.add(fmt.Sprintf("resource '%s'", r.Name), render)
 		}
 	}
 	return nil
 }
 
-func writeOrphanedOperations(writer DocWriter, config *api.Config) error {
+func writeOrphanedOperations(writer DocWriter, config *api.Config, pages *pageQueue) error {
 	// Collect all operation IDs that are orphaned (no definition and not excluded)
 	orphanedIDs := make([]string, 0)
 	for id, o := range config.Operations {
@@ -173,16 +300,18 @@ func writeOrphanedOperations(writer DocWriter, config *api.Config) error {
 
 		// Write documentation for each orphaned operation
 		for _, opKey := range orphanedIDs {
-			if err := writer.WriteOperation(config.Operations[opKey]); err != nil {
+			render, err := writer.WriteOperation(config.Operations[opKey])
+			if err != nil {
 
 				return fmt.Errorf("failed to write orphaned operation '%s': %w", opKey, err)
 			}
+			pages.add(fmt.Sprintf("orphaned operation '%s'", opKey), render)
 		}
 	}
 	return nil
 }
 
-func writeDefinitions(writer DocWriter, config *api.Config) error {
+func writeDefinitions(writer DocWriter, config *api.Config, pages *pageQueue) error {
 	if err := writer.WriteDefinitionsOverview(); err != nil {
 
 		return fmt.Errorf("failed to write definitions overview: %w", err)
@@ -202,16 +331,18 @@ func writeDefinitions(writer DocWriter, config *api.Config) error {
 	sort.Sort(definitions)
 
 	for _, d := range definitions {
-		if err := writer.WriteDefinition(d); err != nil {
+		render, err := writer.WriteDefinition(d)
+		if err != nil {
 
 			return fmt.Errorf("failed to write definition '%s': %w", d.Name, err)
 		}
+		pages.add(fmt.Sprintf("definition '%s'", d.Name), render)
 	}
 	return nil
 }
 
 // writeOldVersions handles documentation for deprecated/old API versions
-func writeOldVersions(writer DocWriter, config *api.Config) error {
+func writeOldVersions(writer DocWriter, config *api.Config, pages *pageQueue) error {
 	if err := writer.WriteOldVersionsOverview(); err != nil {
 
 		return fmt.Errorf("failed to write old versions overview: %w", err)
@@ -238,15 +369,12 @@ func writeOldVersions(writer DocWriter, config *api.Config) error {
 
 		// Create a resource wrapper for the old version definition
 		r := &api.Resource{Definition: d, Name: d.Name}
-		if err := writer.WriteResource(r); err != nil {
+		render, err := writer.WriteResource(r)
+		if err != nil {
 
 			return fmt.Errorf("failed to write old version resource '%s': %w", d.Name, err)
 		}
-	}
-
-	if err := writer.Finalize(); err != nil {
-		// add context to finalize errors
-		return fmt.Errorf("failed to finalize writer: %w", err)
+		pages.add(fmt.Sprintf("old version resource '%s'", d.Name), render)
 	}
 
 	return nil
@@ -279,6 +407,33 @@ func conceptFileName(d *api.Definition) string {
 	return fmt.Sprintf("%s_%s_%s_concept", name, d.Version, d.Group)
 }
 
+// groupVersions is an API group with its versions, for the templates.
+type groupVersions struct {
+	Group    string
+	Versions []string
+}
+
+// sortedGroupVersions returns the API groups and their versions, sorted.
+func sortedGroupVersions(gvs api.GroupVersions) []groupVersions {
+	groups := api.ApiGroups{}
+	for group := range gvs {
+		groups = append(groups, api.ApiGroup(group))
+	}
+	sort.Sort(groups)
+
+	var result []groupVersions
+	for _, group := range groups {
+		versionList := gvs[group.String()]
+		sort.Sort(versionList)
+		var versions []string
+		for _, v := range versionList {
+			versions = append(versions, v.String())
+		}
+		result = append(result, groupVersions{Group: group.String(), Versions: versions})
+	}
+	return result
+}
+
 func getLink(s string) string {
 	tmp := strings.ReplaceAll(s, ".", "-")
 	return strings.ToLower(strings.ReplaceAll(tmp, " ", "-"))
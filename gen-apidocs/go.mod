@@ -5,6 +5,8 @@ go 1.25.0
 require (
 	github.com/go-openapi/loads v0.23.2
 	github.com/go-openapi/spec v0.22.2
+	github.com/kubernetes-sigs/reference-docs/diagnostics v0.0.0
+	github.com/kubernetes-sigs/reference-docs/manifest v0.0.0
 	gopkg.in/yaml.v2 v2.4.0
 )
 
@@ -34,3 +36,8 @@ require (
 	github.com/oklog/ulid v1.3.1 // indirect
 	go.mongodb.org/mongo-driver v1.17.6 // indirect
 )
+
+replace (
+	github.com/kubernetes-sigs/reference-docs/diagnostics => ../diagnostics
+	github.com/kubernetes-sigs/reference-docs/manifest => ../manifest
+)
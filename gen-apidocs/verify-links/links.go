@@ -0,0 +1,534 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The kinds of problems found in the output.
+const (
+	danglingLink    = "dangling-link"
+	duplicateAnchor = "duplicate-anchor"
+)
+
+// problem is a broken link or a duplicate anchor in a file of the output.
+type problem struct {
+	Kind string `json:"kind"`
+	// File is the path of the file, relative to the checked directory.
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Target string `json:"target,omitempty"`
+	Anchor string `json:"anchor,omitempty"`
+	Reason string `json:"reason"`
+}
+
+func (p problem) String() string {
+	switch p.Kind {
+	case danglingLink:
+		return fmt.Sprintf("%s:%d: dangling link to %q: %s", p.File, p.Line, p.Target, p.Reason)
+	default:
+		return fmt.Sprintf("%s:%d: duplicate anchor %q: %s", p.File, p.Line, p.Anchor, p.Reason)
+	}
+}
+
+// report is the result of checking an output directory.
+type report struct {
+	Dir      string    `json:"dir"`
+	Files    int       `json:"files"`
+	Links    int       `json:"links"`
+	Problems []problem `json:"problems"`
+}
+
+// link is a link found in a page.
+type link struct {
+	target string
+	line   int
+	// ref is true for the targets of Hugo ref and relref shortcodes, which
+	// are paths of content files rather than URLs.
+	ref bool
+}
+
+// anchor is an anchor defined in a page.
+type anchor struct {
+	name string
+	line int
+}
+
+// page is a file of the output that links to or defines anchors.
+type page struct {
+	path     string
+	anchors  []anchor
+	links    []link
+	includes []string
+}
+
+var (
+	tagRegex      = regexp.MustCompile(`<[a-zA-Z][^>]*>`)
+	tagNameRegex  = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9]*)`)
+	idAttrRegex   = regexp.MustCompile(`(?i)\sid\s*=\s*"([^"]+)"`)
+	nameAttrRegex = regexp.MustCompile(`(?i)\sname\s*=\s*"([^"]+)"`)
+	hrefRegex     = regexp.MustCompile(`(?i)\shref\s*=\s*"([^"]*)"`)
+
+	headingRegex    = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*(?:\{#([^}]+)\})?\s*$`)
+	mdLinkRegex     = regexp.MustCompile(`\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	refRegex        = regexp.MustCompile(`\{\{<\s*(?:rel)?ref\s+"([^"]*)"\s*>\}\}`)
+	autoIDRegex     = regexp.MustCompile(`[^\p{L}\p{N}\s_-]`)
+	fenceRegex      = regexp.MustCompile("^\\s*(```|~~~)")
+	schemeRegex     = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+	adocAnchorRegex = regexp.MustCompile(`\[\[([^\],]+)(?:,[^\]]*)?\]\]|^\[#([^\],.%]+)`)
+	adocXrefRegex   = regexp.MustCompile(`<<([^,>]+)(?:,[^>]*)?>>`)
+	adocIncRegex    = regexp.MustCompile(`^include::([^\[]+)\[`)
+)
+
+// parsePage reads the anchors and the links of a file according to its
+// type. It returns nil for the files which are neither HTML, Markdown nor
+// AsciiDoc.
+func parsePage(file string) (*page, error) {
+	var parse func(p *page, lines []string)
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".html", ".htm":
+		parse = parseHTML
+	case ".md":
+		parse = parseMarkdown
+	case ".adoc":
+		parse = parseAsciiDoc
+	default:
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	p := &page{path: file}
+	parse(p, strings.Split(string(data), "\n"))
+	return p, nil
+}
+
+// parseHTML reads the id attributes and the names of the <a> elements as
+// anchors, and the href attributes as links.
+func parseHTML(p *page, lines []string) {
+	for i, line := range lines {
+		for _, tag := range tagRegex.FindAllString(line, -1) {
+			seen := map[string]bool{}
+			for _, m := range idAttrRegex.FindAllStringSubmatch(tag, -1) {
+				seen[m[1]] = true
+				p.anchors = append(p.anchors, anchor{name: m[1], line: i + 1})
+			}
+			if name := tagNameRegex.FindStringSubmatch(tag); name != nil && strings.EqualFold(name[1], "a") {
+				for _, m := range nameAttrRegex.FindAllStringSubmatch(tag, -1) {
+					if !seen[m[1]] {
+						p.anchors = append(p.anchors, anchor{name: m[1], line: i + 1})
+					}
+				}
+			}
+			for _, m := range hrefRegex.FindAllStringSubmatch(tag, -1) {
+				p.links = append(p.links, link{target: m[1], line: i + 1})
+			}
+		}
+	}
+}
+
+// parseMarkdown reads the headings and the HTML anchors as anchors, and the
+// Markdown links, the HTML links and the Hugo ref shortcodes as links. The
+// front matter and the code blocks are skipped.
+func parseMarkdown(p *page, lines []string) {
+	start := 0
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == "---" {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	autoIDs := map[string]int{}
+	fence := ""
+	for i := start; i < len(lines); i++ {
+		line := lines[i]
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			if fence == "" {
+				fence = m[1]
+			} else if fence == m[1] {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+
+		if m := headingRegex.FindStringSubmatch(line); m != nil {
+			if m[2] != "" {
+				p.anchors = append(p.anchors, anchor{name: m[2], line: i + 1})
+			} else {
+				// Hugo generates the missing ids, adding a number to the
+				// ones already used in the page.
+				id := autoID(m[1])
+				if n := autoIDs[id]; n > 0 {
+					autoIDs[id]++
+					id = fmt.Sprintf("%s-%d", id, n)
+				} else {
+					autoIDs[id] = 1
+				}
+				p.anchors = append(p.anchors, anchor{name: id, line: i + 1})
+			}
+		}
+
+		for _, m := range refRegex.FindAllStringSubmatch(line, -1) {
+			p.links = append(p.links, link{target: m[1], line: i + 1, ref: true})
+		}
+		for _, m := range mdLinkRegex.FindAllStringSubmatch(line, -1) {
+			p.links = append(p.links, link{target: m[1], line: i + 1})
+		}
+	}
+
+	// The HTML markup of the page, the code blocks excluded.
+	var html []string
+	fence = ""
+	for i, line := range lines {
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			if fence == "" {
+				fence = m[1]
+			} else if fence == m[1] {
+				fence = ""
+			}
+		}
+		if fence != "" || i < start {
+			line = ""
+		}
+		html = append(html, line)
+	}
+	parseHTML(p, html)
+}
+
+// autoID returns the id Hugo generates for a heading without one.
+func autoID(title string) string {
+	title = tagRegex.ReplaceAllString(title, "")
+	title = strings.ReplaceAll(title, "`", "")
+	title = autoIDRegex.ReplaceAllString(strings.ToLower(title), "")
+	return strings.ReplaceAll(strings.TrimSpace(title), " ", "-")
+}
+
+// parseAsciiDoc reads the anchors, the cross references and the included
+// files of an AsciiDoc file.
+func parseAsciiDoc(p *page, lines []string) {
+	for i, line := range lines {
+		for _, m := range adocAnchorRegex.FindAllStringSubmatch(line, -1) {
+			name := m[1]
+			if name == "" {
+				name = m[2]
+			}
+			p.anchors = append(p.anchors, anchor{name: name, line: i + 1})
+		}
+		// The cross references link to the anchors of the document.
+		for _, m := range adocXrefRegex.FindAllStringSubmatch(line, -1) {
+			p.links = append(p.links, link{target: "#" + m[1], line: i + 1})
+		}
+		if m := adocIncRegex.FindStringSubmatch(line); m != nil {
+			p.includes = append(p.includes, m[1])
+		}
+	}
+}
+
+// checker checks the links of the pages under a directory.
+type checker struct {
+	dir string
+	// skip are the names of the directories whose files are only checked
+	// when they are included by another file.
+	skip map[string]bool
+	// ignore matches the targets of the links not to check, nil to check
+	// all the links.
+	ignore *regexp.Regexp
+	pages  map[string]*page
+}
+
+func newChecker(dir string, skip []string, ignore *regexp.Regexp) *checker {
+	c := &checker{dir: dir, skip: map[string]bool{}, ignore: ignore, pages: map[string]*page{}}
+	for _, s := range skip {
+		if s != "" {
+			c.skip[s] = true
+		}
+	}
+	return c
+}
+
+// page returns the parsed page of a file, nil if the file is not a page.
+func (c *checker) page(file string) (*page, error) {
+	if p, found := c.pages[file]; found {
+		return p, nil
+	}
+	p, err := parsePage(file)
+	if err != nil {
+		return nil, err
+	}
+	c.pages[file] = p
+	return p, nil
+}
+
+// check checks all the pages under the directory.
+func (c *checker) check() (*report, error) {
+	r := &report{Dir: c.dir, Problems: []problem{}}
+	err := filepath.WalkDir(c.dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if file != c.dir && c.skip[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		p, err := c.page(file)
+		if err != nil || p == nil {
+			return err
+		}
+		// AsciiDoc files are checked as part of the document including them.
+		if strings.HasSuffix(file, ".adoc") && c.included(file) {
+			return nil
+		}
+		r.Files++
+		problems, links, err := c.checkPage(p)
+		if err != nil {
+			return err
+		}
+		r.Links += links
+		r.Problems = append(r.Problems, problems...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(r.Problems, func(i, j int) bool {
+		if r.Problems[i].File != r.Problems[j].File {
+			return r.Problems[i].File < r.Problems[j].File
+		}
+		return r.Problems[i].Line < r.Problems[j].Line
+	})
+	return r, nil
+}
+
+// included returns true if an AsciiDoc file is included by another file of
+// the directory, based on the include directives of the top-level files.
+func (c *checker) included(file string) bool {
+	entries, err := os.ReadDir(filepath.Dir(file))
+	if err != nil {
+		return false
+	}
+	// A file in a skipped directory can only be reached by an include.
+	for dir := filepath.Dir(file); dir != c.dir && dir != "." && dir != "/"; dir = filepath.Dir(dir) {
+		if c.skip[filepath.Base(dir)] {
+			return true
+		}
+	}
+	for _, e := range entries {
+		other := filepath.Join(filepath.Dir(file), e.Name())
+		if e.IsDir() || other == file || !strings.HasSuffix(other, ".adoc") {
+			continue
+		}
+		p, err := c.page(other)
+		if err != nil || p == nil {
+			continue
+		}
+		for _, inc := range p.includes {
+			if filepath.Join(filepath.Dir(other), filepath.FromSlash(inc)) == file {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// document returns the pages forming the document of a page: the page
+// itself for HTML and Markdown, the page and the files it includes for
+// AsciiDoc.
+func (c *checker) document(p *page) ([]*page, error) {
+	doc := []*page{p}
+	for i := 0; i < len(doc); i++ {
+		for _, inc := range doc[i].includes {
+			file := filepath.Join(filepath.Dir(doc[i].path), filepath.FromSlash(inc))
+			included, err := c.page(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s included by %s: %w", file, doc[i].path, err)
+			}
+			if included != nil {
+				doc = append(doc, included)
+			}
+		}
+	}
+	return doc, nil
+}
+
+// anchors returns the anchors of the document of a page.
+func (c *checker) anchors(p *page) (map[string]bool, error) {
+	doc, err := c.document(p)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, part := range doc {
+		for _, a := range part.anchors {
+			names[a.name] = true
+		}
+	}
+	return names, nil
+}
+
+// checkPage checks the anchors and the links of a page. It returns the
+// problems found and the number of internal links checked.
+func (c *checker) checkPage(p *page) ([]problem, int, error) {
+	doc, err := c.document(p)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var problems []problem
+	type definition struct {
+		file string
+		line int
+	}
+	defined := map[string]definition{}
+	for _, part := range doc {
+		for _, a := range part.anchors {
+			if first, found := defined[a.name]; found {
+				problems = append(problems, problem{
+					Kind:   duplicateAnchor,
+					File:   c.rel(part.path),
+					Line:   a.line,
+					Anchor: a.name,
+					Reason: fmt.Sprintf("already defined at %s:%d", c.rel(first.file), first.line),
+				})
+				continue
+			}
+			defined[a.name] = definition{file: part.path, line: a.line}
+		}
+	}
+
+	count := 0
+	for _, part := range doc {
+		for _, l := range part.links {
+			target, fragment, internal := splitTarget(l)
+			if !internal || (c.ignore != nil && c.ignore.MatchString(l.target)) {
+				continue
+			}
+			count++
+
+			var reason string
+			if target == "" {
+				if fragment != "" {
+					if _, found := defined[fragment]; !found {
+						reason = fmt.Sprintf("no anchor %q in the page", fragment)
+					}
+				}
+			} else {
+				reason, err = c.checkTarget(part, l, target, fragment)
+				if err != nil {
+					return nil, 0, err
+				}
+			}
+			if reason != "" {
+				problems = append(problems, problem{
+					Kind:   danglingLink,
+					File:   c.rel(part.path),
+					Line:   l.line,
+					Target: l.target,
+					Reason: reason,
+				})
+			}
+		}
+	}
+	return problems, count, nil
+}
+
+// checkTarget checks a link to another file and returns why the link is
+// dangling, or an empty string.
+func (c *checker) checkTarget(from *page, l link, target, fragment string) (string, error) {
+	base := filepath.Join(c.dir, filepath.Dir(c.rel(from.path)))
+	file := filepath.Join(base, filepath.FromSlash(target))
+	if strings.HasPrefix(target, "/") {
+		file = filepath.Join(c.dir, filepath.FromSlash(target))
+	}
+
+	// The targets of the ref shortcodes are content files, with or without
+	// extension. The other targets are URLs, where directories stand for
+	// their index page.
+	candidates := []string{file, file + ".md", filepath.Join(file, "_index.md"), filepath.Join(file, "index.md")}
+	if !l.ref {
+		candidates = []string{file, filepath.Join(file, "index.html"), filepath.Join(file, "_index.md"), filepath.Join(file, "index.md"), file + ".md"}
+	}
+	found := ""
+	for _, candidate := range candidates {
+		if fi, err := os.Stat(candidate); err == nil && !fi.IsDir() {
+			found = candidate
+			break
+		}
+	}
+	if found == "" {
+		return fmt.Sprintf("no file %s", c.rel(file)), nil
+	}
+	if fragment == "" {
+		return "", nil
+	}
+
+	p, err := c.page(found)
+	if err != nil || p == nil {
+		return "", err
+	}
+	names, err := c.anchors(p)
+	if err != nil {
+		return "", err
+	}
+	if !names[fragment] {
+		return fmt.Sprintf("no anchor %q in %s", fragment, c.rel(found)), nil
+	}
+	return "", nil
+}
+
+// splitTarget splits the target of a link into the path and the fragment.
+// It returns false for the links which are not checked: the links to other
+// sites, to other paths of the site and the links built by templates.
+func splitTarget(l link) (string, string, bool) {
+	t := l.target
+	if t == "" || strings.Contains(t, "{{") || schemeRegex.MatchString(t) || strings.HasPrefix(t, "//") {
+		return "", "", false
+	}
+	if strings.HasPrefix(t, "/") && !l.ref {
+		return "", "", false
+	}
+	target, fragment, _ := strings.Cut(t, "#")
+	target, _, _ = strings.Cut(target, "?")
+	return target, fragment, true
+}
+
+// rel returns the slash-separated path of a file relative to the directory.
+func (c *checker) rel(file string) string {
+	rel, err := filepath.Rel(c.dir, file)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	return path.Clean(filepath.ToSlash(rel))
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+// writeTree writes the files, keyed by their slash-separated path, under a
+// temporary directory and returns the directory.
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		name   string
+		files  map[string]string
+		ignore string
+		links  int
+		want   []problem
+	}{
+		{
+			name: "html",
+			files: map[string]string{
+				"index.html": `<html>
+<h1 id="pod-v1-core">Pod</h1>
+<a href="#pod-v1-core">Pod</a>
+<a href="#service-v1-core">Service</a>
+<a href="other.html#top">Other</a>
+<a href="missing.html">Missing</a>
+<a href="https://kubernetes.io/">Kubernetes</a>
+<link rel="icon" href="favicon.ico">
+</html>`,
+				"other.html": `<a name="top"></a>`,
+			},
+			ignore: `favicon\.ico$`,
+			links:  4,
+			want: []problem{
+				{Kind: danglingLink, File: "index.html", Line: 4, Target: "#service-v1-core", Reason: `no anchor "service-v1-core" in the page`},
+				{Kind: danglingLink, File: "index.html", Line: 6, Target: "missing.html", Reason: "no file missing.html"},
+			},
+		},
+		{
+			name: "markdown",
+			files: map[string]string{
+				"_index.md": `---
+title: "API"
+---
+## Pod v1 core
+[Pod](#pod-v1-core)
+[Service](services/service#service-v1-core)
+[Lease]({{< ref "cluster/lease#Lease" >}})
+` + "```\n[not a link](#nothing)\n```\n",
+				"services/service.md": `## Service {#service-v1-core}`,
+				"cluster/lease.md":    `## LeaseSpec {#LeaseSpec}`,
+			},
+			links: 3,
+			want: []problem{
+				{Kind: danglingLink, File: "_index.md", Line: 7, Target: "cluster/lease#Lease", Reason: `no anchor "Lease" in cluster/lease.md`},
+			},
+		},
+		{
+			name: "kwebsite",
+			files: map[string]string{
+				"workload-resources/pod-v1.md": `## Pod {#Pod}
+- **metadata** (<a href="{{< ref "../common-definitions/object-meta#ObjectMeta" >}}">ObjectMeta</a>)
+  <a name="Time"></a>
+  <a name="Time"></a>
+`,
+				"common-definitions/object-meta.md": `<a name="ObjectMeta"></a>`,
+			},
+			links: 1,
+			want: []problem{
+				{Kind: duplicateAnchor, File: "workload-resources/pod-v1.md", Line: 4, Anchor: "Time", Reason: "already defined at workload-resources/pod-v1.md:3"},
+			},
+		},
+		{
+			name: "asciidoc",
+			files: map[string]string{
+				"index.adoc": `= API
+include::includes/pod.adoc[]
+<<pod-v1-core,Pod>>
+<<service-v1-core,Service>>`,
+				"includes/pod.adoc": `[[pod-v1-core]]
+== Pod
+[#pod-v1-core]`,
+			},
+			links: 2,
+			want: []problem{
+				{Kind: duplicateAnchor, File: "includes/pod.adoc", Line: 3, Anchor: "pod-v1-core", Reason: "already defined at includes/pod.adoc:1"},
+				{Kind: danglingLink, File: "index.adoc", Line: 4, Target: "#service-v1-core", Reason: `no anchor "service-v1-core" in the page`},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeTree(t, tc.files)
+			var ignore *regexp.Regexp
+			if tc.ignore != "" {
+				ignore = regexp.MustCompile(tc.ignore)
+			}
+			r, err := newChecker(dir, []string{"includes"}, ignore).check()
+			if err != nil {
+				t.Fatalf("check failed: %v", err)
+			}
+			if r.Links != tc.links {
+				t.Errorf("checked %d links, want %d", r.Links, tc.links)
+			}
+			if len(r.Problems) != len(tc.want) {
+				t.Fatalf("got problems %v, want %v", r.Problems, tc.want)
+			}
+			for i := range tc.want {
+				if r.Problems[i] != tc.want[i] {
+					t.Errorf("got problem %v, want %v", r.Problems[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// verify-links checks the links of the reference generated by any of the
+// backends of the generators: the HTML and Markdown pages of gen-apidocs,
+// the AsciiDoc book, the k/website pages of gen-resourcesdocs and the pages
+// of genref. It reports the internal links whose file or anchor does not
+// exist and the anchors defined more than once in a page.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	format = flag.String("format", "text", "Format of the report, 'text' or 'json'.")
+	skip   = flag.String("skip", "includes", "Comma-separated names of the directories of intermediate files, only checked when included by another file.")
+	ignore = flag.String("ignore", "", "Regular expression matching the targets of the links not to check, like the files provided by the website.")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] <dir>...\n\n"+
+			"Checks the links of the files generated in each directory.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 || (*format != "text" && *format != "json") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var ignoreRegex *regexp.Regexp
+	if *ignore != "" {
+		var err error
+		if ignoreRegex, err = regexp.Compile(*ignore); err != nil {
+			log.Fatalf("invalid -ignore expression: %v", err)
+		}
+	}
+
+	var reports []*report
+	failed := false
+	for _, dir := range flag.Args() {
+		r, err := newChecker(dir, strings.Split(*skip, ","), ignoreRegex).check()
+		if err != nil {
+			log.Fatalf("failed to check %s: %v", dir, err)
+		}
+		reports = append(reports, r)
+		if len(r.Problems) > 0 {
+			failed = true
+		}
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(reports); err != nil {
+			log.Fatalf("failed to write the report: %v", err)
+		}
+	} else {
+		for _, r := range reports {
+			for _, p := range r.Problems {
+				fmt.Printf("%s/%s\n", strings.TrimSuffix(r.Dir, "/"), p)
+			}
+			fmt.Printf("%s: %d files, %d links checked, %d problems\n", r.Dir, r.Files, r.Links, len(r.Problems))
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
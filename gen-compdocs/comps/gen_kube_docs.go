@@ -14,93 +14,101 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// Package comps registers the Kubernetes components with gendocs.
 package comps
 
 import (
 	"context"
 	goflag "flag"
-	"fmt"
 	"os"
 
+	"github.com/kubernetes-sigs/reference-docs/gen-compdocs/gendocs"
 	"github.com/kubernetes-sigs/reference-docs/gen-compdocs/generators"
+	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	cliflag "k8s.io/component-base/cli/flag"
+	"k8s.io/klog/v2"
 	kubectlcmd "k8s.io/kubectl/pkg/cmd"
-	"k8s.io/kubernetes/cmd/genutils"
 	apiservapp "k8s.io/kubernetes/cmd/kube-apiserver/app"
 	cmapp "k8s.io/kubernetes/cmd/kube-controller-manager/app"
+	proxyapp "k8s.io/kubernetes/cmd/kube-proxy/app"
 	schapp "k8s.io/kubernetes/cmd/kube-scheduler/app"
 	kubeadmapp "k8s.io/kubernetes/cmd/kubeadm/app/cmd"
 	kubeletapp "k8s.io/kubernetes/cmd/kubelet/app"
 	kubeletoptions "k8s.io/kubernetes/cmd/kubelet/app/options"
 )
 
-func GenerateFiles(path, module string) error {
-	outDir, err := genutils.OutDir(path)
-	if err != nil {
-		return fmt.Errorf("failed to get output directory: %w", err)
-	}
-
-	switch module {
-	case "kube-apiserver":
-		pflag.CommandLine.SetNormalizeFunc(cliflag.WordSepNormalizeFunc)
-		apiserver := apiservapp.NewAPIServerCommand()
-		return generators.GenMarkdownTree(apiserver, outDir, true)
-
-	case "kube-controller-manager":
-		pflag.CommandLine.SetNormalizeFunc(cliflag.WordSepNormalizeFunc)
-		controllermanager := cmapp.NewControllerManagerCommand()
-		return generators.GenMarkdownTree(controllermanager, outDir, true)
-
-	case "kube-scheduler":
-		pflag.CommandLine.SetNormalizeFunc(cliflag.WordSepNormalizeFunc)
-		scheduler := schapp.NewSchedulerCommand()
-		return generators.GenMarkdownTree(scheduler, outDir, true)
-
-	case "kubelet":
-		kubelet := kubeletapp.NewKubeletCommand(context.TODO())
-		kubeletFlags := kubeletoptions.NewKubeletFlags()
-		kubeletFlags.AddFlags(kubelet.Flags())
-		config, _ := kubeletoptions.NewKubeletConfiguration()
-		kubeletoptions.AddKubeletConfigFlags(kubelet.Flags(), config)
-		kubeletoptions.AddGlobalFlags(kubelet.Flags())
-		return generators.GenMarkdownTree(kubelet, outDir, true)
-
-	case "kubeadm":
-		pflag.CommandLine = pflag.NewFlagSet(os.Args[0], pflag.ExitOnError)
-		pflag.CommandLine.SetNormalizeFunc(cliflag.WordSepNormalizeFunc)
-		// Ignore irrelevant flags
-		// pflag.CommandLine.AddGoFlagSet(goflag.CommandLine)
-
-		pflag.Set("logtostderr", "true")
-		// We do not want these flags to show up in --help
-		// These MarkHidden calls must be after the lines above
-		pflag.CommandLine.MarkHidden("version")
-		pflag.CommandLine.MarkHidden("log-flush-frequency")
-		pflag.CommandLine.MarkHidden("alsologtostderr")
-		pflag.CommandLine.MarkHidden("log-backtrace-at")
-		pflag.CommandLine.MarkHidden("log-dir")
-		pflag.CommandLine.MarkHidden("logtostderr")
-		pflag.CommandLine.MarkHidden("stderrthreshold")
-		pflag.CommandLine.MarkHidden("vmodule")
-
-		// generate docs for kubeadm
-		kubeadm := kubeadmapp.NewKubeadmCommand(os.Stdin, os.Stdout, os.Stderr)
-		if err := generators.GenMarkdownTree(kubeadm, outDir, false); err != nil {
-			return fmt.Errorf("failed to generate markdown tree: %w", err)
-		}
-
-		// cleanup generated code for usage as include in the website
-		return generators.MarkdownPostProcessing(kubeadm, outDir, "", generators.CleanupForInclude)
-
-	case "kubectl":
-		kubectl := kubectlcmd.NewDefaultKubectlCommand()
-		pflag.CommandLine.SetNormalizeFunc(cliflag.WordSepNormalizeFunc)
-		pflag.CommandLine.AddGoFlagSet(goflag.CommandLine)
-
-		return generators.GenMarkdownTree(kubectl, outDir, true)
-
-	default:
-		return fmt.Errorf("module %s is not supported", module)
-	}
+func init() {
+	gendocs.Register("kube-apiserver", newAPIServerCommand)
+	gendocs.Register("kube-controller-manager", newControllerManagerCommand)
+	gendocs.Register("kube-scheduler", newSchedulerCommand)
+	gendocs.Register("kube-proxy", newProxyCommand)
+	gendocs.Register("kubelet", newKubeletCommand)
+	// The kubeadm pages are included in the kubeadm pages of the website.
+	gendocs.Register("kubeadm", newKubeadmCommand, gendocs.Snippets())
+	gendocs.Register("kubectl", newKubectlCommand)
+}
+
+// The constructors below create the root command of each module with all its
+// flags set up the same way as the component does.
+
+func newAPIServerCommand() *cobra.Command {
+	pflag.CommandLine.SetNormalizeFunc(cliflag.WordSepNormalizeFunc)
+	return apiservapp.NewAPIServerCommand()
+}
+
+func newControllerManagerCommand() *cobra.Command {
+	pflag.CommandLine.SetNormalizeFunc(cliflag.WordSepNormalizeFunc)
+	return cmapp.NewControllerManagerCommand()
+}
+
+func newSchedulerCommand() *cobra.Command {
+	pflag.CommandLine.SetNormalizeFunc(cliflag.WordSepNormalizeFunc)
+	return schapp.NewSchedulerCommand()
+}
+
+func newProxyCommand() *cobra.Command {
+	// The klog flags are only registered for kube-proxy, the other modules
+	// would otherwise pick them up from the Go flag set.
+	klog.InitFlags(nil)
+	return proxyapp.NewProxyCommand()
+}
+
+func newKubeletCommand() *cobra.Command {
+	kubelet := kubeletapp.NewKubeletCommand(context.TODO())
+	kubeletFlags := kubeletoptions.NewKubeletFlags()
+	kubeletFlags.AddFlags(kubelet.Flags())
+	config, _ := kubeletoptions.NewKubeletConfiguration()
+	kubeletoptions.AddKubeletConfigFlags(kubelet.Flags(), config)
+	kubeletoptions.AddGlobalFlags(kubelet.Flags())
+	generators.SetConfigFields(kubeletConfigFields())
+	return kubelet
+}
+
+func newKubeadmCommand() *cobra.Command {
+	pflag.CommandLine = pflag.NewFlagSet(os.Args[0], pflag.ExitOnError)
+	pflag.CommandLine.SetNormalizeFunc(cliflag.WordSepNormalizeFunc)
+	// Ignore irrelevant flags
+	// pflag.CommandLine.AddGoFlagSet(goflag.CommandLine)
+
+	pflag.Set("logtostderr", "true")
+	// We do not want these flags to show up in --help
+	// These MarkHidden calls must be after the lines above
+	pflag.CommandLine.MarkHidden("version")
+	pflag.CommandLine.MarkHidden("log-flush-frequency")
+	pflag.CommandLine.MarkHidden("alsologtostderr")
+	pflag.CommandLine.MarkHidden("log-backtrace-at")
+	pflag.CommandLine.MarkHidden("log-dir")
+	pflag.CommandLine.MarkHidden("logtostderr")
+	pflag.CommandLine.MarkHidden("stderrthreshold")
+	pflag.CommandLine.MarkHidden("vmodule")
+
+	return kubeadmapp.NewKubeadmCommand(os.Stdin, os.Stdout, os.Stderr)
+}
+
+func newKubectlCommand() *cobra.Command {
+	kubectl := kubectlcmd.NewDefaultKubectlCommand()
+	pflag.CommandLine.SetNormalizeFunc(cliflag.WordSepNormalizeFunc)
+	pflag.CommandLine.AddGoFlagSet(goflag.CommandLine)
+	return kubectl
 }
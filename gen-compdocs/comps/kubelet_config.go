@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package comps
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-compdocs/generators"
+	"github.com/spf13/pflag"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+	kubeletoptions "k8s.io/kubernetes/cmd/kubelet/app/options"
+)
+
+// kubeletConfigLink is the KubeletConfiguration type in the kubelet config
+// API reference generated by genref. The reference has no anchors for the
+// fields, so the flags link to the type.
+const kubeletConfigLink = "/docs/reference/config-api/kubelet-config.v1beta1/#kubelet-config-k8s-io-v1beta1-KubeletConfiguration"
+
+// fieldAddr is a field in the internal KubeletConfiguration struct.
+type fieldAddr struct {
+	addr uintptr
+	kind reflect.Kind
+	path []string
+}
+
+// kubeletConfigFields maps the kubelet flags that are backed by the
+// KubeletConfiguration to the fields in the v1beta1 config file.
+//
+// The flags are bound to the fields of the internal config struct, so a flag
+// is matched to a field by comparing the address its value points to.
+func kubeletConfigFields() map[string]generators.ConfigField {
+	config, _ := kubeletoptions.NewKubeletConfiguration()
+	fs := pflag.NewFlagSet("kubelet-config", pflag.ContinueOnError)
+	kubeletoptions.AddKubeletConfigFlags(fs, config)
+
+	fields := collectFields(reflect.ValueOf(config).Elem(), nil, nil)
+	v1beta1 := reflect.TypeOf(kubeletconfigv1beta1.KubeletConfiguration{})
+
+	result := make(map[string]generators.ConfigField)
+	fs.VisitAll(func(flag *pflag.Flag) {
+		path := matchField(reflect.ValueOf(flag.Value), fields)
+		if path == nil {
+			return
+		}
+		name := jsonPath(v1beta1, path)
+		if name == "" {
+			return
+		}
+		result[flag.Name] = generators.ConfigField{
+			Name: name,
+			Link: kubeletConfigLink,
+		}
+	})
+	return result
+}
+
+// collectFields lists the addressable fields of a struct, nested ones
+// included.
+func collectFields(v reflect.Value, path []string, result []fieldAddr) []fieldAddr {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		p := append(append([]string{}, path...), v.Type().Field(i).Name)
+		result = append(result, fieldAddr{addr: f.Addr().Pointer(), kind: f.Kind(), path: p})
+		if f.Kind() == reflect.Struct {
+			result = collectFields(f, p, result)
+		}
+	}
+	return result
+}
+
+// matchField finds the field a flag value is bound to. Values such as
+// cliflag.MapStringBool wrap a pointer to the field instead.
+func matchField(value reflect.Value, fields []fieldAddr) []string {
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return nil
+	}
+	elem := value.Elem()
+	if path := lookupField(value.Pointer(), elem.Kind(), fields); path != nil {
+		return path
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < elem.NumField(); i++ {
+		f := elem.Field(i)
+		if f.Kind() != reflect.Ptr || f.IsNil() {
+			continue
+		}
+		if path := lookupField(f.Pointer(), f.Elem().Kind(), fields); path != nil {
+			return path
+		}
+	}
+	return nil
+}
+
+// lookupField returns the path of the field at the address. A struct shares
+// its address with its first field, so the kind has to match as well.
+func lookupField(addr uintptr, kind reflect.Kind, fields []fieldAddr) []string {
+	for _, f := range fields {
+		if f.addr == addr && f.kind == kind {
+			return f.path
+		}
+	}
+	return nil
+}
+
+// jsonPath converts a path of Go field names into the dotted path of JSON
+// field names in the versioned type. Fields without a JSON name, such as
+// metav1.Duration.Duration, are skipped. It returns an empty string if the
+// field is not found in the versioned type.
+func jsonPath(t reflect.Type, path []string) string {
+	var names []string
+	for _, name := range path {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return ""
+		}
+		f, ok := t.FieldByName(name)
+		if !ok {
+			return ""
+		}
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		if tag != "" && tag != "-" {
+			names = append(names, tag)
+		}
+		t = f.Type
+	}
+	return strings.Join(names, ".")
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package comps
+
+import (
+	"testing"
+)
+
+func TestKubeletConfigFields(t *testing.T) {
+	fields := kubeletConfigFields()
+
+	cases := []struct {
+		flag  string
+		field string
+	}{
+		{flag: "max-pods", field: "maxPods"},
+		{flag: "cpu-manager-policy", field: "cpuManagerPolicy"},
+		// Nested fields.
+		{flag: "anonymous-auth", field: "authentication.anonymous.enabled"},
+		{flag: "client-ca-file", field: "authentication.x509.clientCAFile"},
+		// Bound through a pointer wrapped by the flag value.
+		{flag: "feature-gates", field: "featureGates"},
+		{flag: "eviction-hard", field: "evictionHard"},
+		// Bound to the Duration of a metav1.Duration.
+		{flag: "authentication-token-webhook-cache-ttl", field: "authentication.webhook.cacheTTL"},
+		// Flags without a config field.
+		{flag: "kubeconfig"},
+		{flag: "v"},
+	}
+	for _, c := range cases {
+		field, ok := fields[c.flag]
+		if c.field == "" {
+			if ok {
+				t.Errorf("--%s is mapped to %s, expected no config field", c.flag, field.Name)
+			}
+			continue
+		}
+		if !ok {
+			t.Errorf("--%s is not mapped, expected %s", c.flag, c.field)
+			continue
+		}
+		if field.Name != c.field || field.Link != kubeletConfigLink {
+			t.Errorf("--%s is mapped to %+v, expected %s linked to %s", c.flag, field, c.field, kubeletConfigLink)
+		}
+	}
+}
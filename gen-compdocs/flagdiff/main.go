@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-compdocs/generators"
+)
+
+func main() {
+	if len(os.Args) != 3 {
+		log.Fatalf("usage: %s <previous> <current>\n\n"+
+			"Both arguments are flag dumps of a component, or directories of flag dumps.",
+			os.Args[0])
+	}
+
+	if err := diff(os.Args[1], os.Args[2]); err != nil {
+		log.Fatalf("failure: %v", err)
+	}
+}
+
+// diff prints the flag changes between two flag dumps, or between the dumps
+// of the same name in two directories.
+func diff(prev, cur string) error {
+	fi, err := os.Stat(prev)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return diffFiles(prev, cur)
+	}
+
+	prevFiles, err := dumpFiles(prev)
+	if err != nil {
+		return err
+	}
+	curFiles, err := dumpFiles(cur)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for name := range curFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !prevFiles[name] {
+			fmt.Printf("## %s\n\nNew component.\n\n", componentName(name))
+			continue
+		}
+		if err := diffFiles(filepath.Join(prev, name), filepath.Join(cur, name)); err != nil {
+			return err
+		}
+	}
+	names = nil
+	for name := range prevFiles {
+		if !curFiles[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("## %s\n\nComponent removed.\n\n", componentName(name))
+	}
+	return nil
+}
+
+func diffFiles(prev, cur string) error {
+	prevData, err := generators.LoadFlagData(prev)
+	if err != nil {
+		return err
+	}
+	curData, err := generators.LoadFlagData(cur)
+	if err != nil {
+		return err
+	}
+	return generators.DiffFlagData(prevData, curData).WriteMarkdown(os.Stdout)
+}
+
+// dumpFiles returns the names of the flag dumps in a directory.
+func dumpFiles(dir string) (map[string]bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]bool)
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if !e.IsDir() && (ext == ".yaml" || ext == ".json") {
+			files[e.Name()] = true
+		}
+	}
+	return files, nil
+}
+
+func componentName(filename string) string {
+	return strings.TrimSuffix(filename, filepath.Ext(filename))
+}
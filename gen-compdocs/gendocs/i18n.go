@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gendocs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-compdocs/generators"
+)
+
+// kubectlLocales maps the language codes of the website to the locales of
+// the kubectl translation catalogs. A language is only supported once the
+// strings added by the generator are translated into it, see
+// generators.HasCatalog.
+var kubectlLocales = map[string]string{
+	"de":    "de_DE",
+	"fr":    "fr_FR",
+	"it":    "it_IT",
+	"ja":    "ja_JP",
+	"ko":    "ko_KR",
+	"pt-br": "pt_BR",
+	"zh-cn": "zh_CN",
+	"zh-tw": "zh_TW",
+}
+
+// parseLang removes the "--lang <code>" or "--lang=<code>" option from the
+// arguments and returns the language code, empty if the option is not given.
+func parseLang(args []string) ([]string, string, error) {
+	rest, lang, err := cutOption(args, "lang")
+	if err != nil {
+		return nil, "", err
+	}
+	if lang != "" {
+		if _, ok := kubectlLocales[lang]; !ok || !generators.HasCatalog(lang) {
+			return nil, "", fmt.Errorf("language %s is not supported, supported languages: %s", lang, strings.Join(languages(), ", "))
+		}
+	}
+	return rest, lang, nil
+}
+
+// languages returns the supported language codes, sorted.
+func languages() []string {
+	var codes []string
+	for code := range kubectlLocales {
+		if generators.HasCatalog(code) {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// useLanguage generates the pages in the language. kubectl translates its
+// descriptions when its packages are initialized, using the locale of the
+// environment, so the generator is run again with the locale of the language
+// unless it is already set. args are the arguments of the generator, passed
+// again to the new process.
+func useLanguage(lang string, args []string) error {
+	locale := kubectlLocales[lang] + ".UTF-8"
+	if os.Getenv("LC_ALL") != locale {
+		exe, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("failed to find the executable: %w", err)
+		}
+		cmd := exec.Command(exe, args...)
+		cmd.Env = append(os.Environ(), "LC_ALL="+locale)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.ExitCode())
+			}
+			return err
+		}
+		os.Exit(0)
+	}
+
+	generators.SetLanguage(lang)
+	return nil
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gendocs generates the reference docs of the registered modules. It
+// does not depend on any Kubernetes component, so that the docs of other
+// programs can be generated without importing them; the Kubernetes
+// components are registered by the comps package.
+package gendocs
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-compdocs/generators"
+	"github.com/spf13/cobra"
+)
+
+// CommandFunc creates the root command of a module.
+type CommandFunc func() *cobra.Command
+
+// module is a registered module.
+type module struct {
+	newCommand CommandFunc
+	// snippets generates pages without a title, to be included in other
+	// pages.
+	snippets bool
+}
+
+// Option changes how the docs of a registered module are generated.
+type Option func(*module)
+
+// Snippets generates the Markdown pages of the module as snippets without a
+// title and a "SEE ALSO" section, to be included in hand-written pages, as
+// the website does for kubeadm.
+func Snippets() Option {
+	return func(m *module) {
+		m.snippets = true
+	}
+}
+
+// registry holds the modules which can be documented, by name.
+var registry = make(map[string]*module)
+
+// Register adds a module which can be documented. Any program exposing the
+// constructor of its root command can be documented by registering it from a
+// small main package which then calls Main:
+//
+//	func main() {
+//		gendocs.Register("mycli", cmd.NewRootCommand)
+//		gendocs.Main()
+//	}
+//
+// Register panics if the name is already registered.
+func Register(name string, newCommand CommandFunc, opts ...Option) {
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("module %s is already registered", name))
+	}
+	m := &module{newCommand: newCommand}
+	for _, opt := range opts {
+		opt(m)
+	}
+	registry[name] = m
+}
+
+// Modules returns the names of the registered modules, sorted.
+func Modules() []string {
+	var names []string
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookup(name string) (*module, error) {
+	m, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("module %s is not supported, supported modules: %s", name, strings.Join(Modules(), ", "))
+	}
+	return m, nil
+}
+
+// NewCommand creates the root command of a registered module.
+func NewCommand(name string) (*cobra.Command, error) {
+	m, err := lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return m.newCommand(), nil
+}
+
+// GenerateFiles writes the Markdown pages of a module for the website.
+func GenerateFiles(path, name string) error {
+	outDir, err := outDir(path)
+	if err != nil {
+		return fmt.Errorf("failed to get output directory: %w", err)
+	}
+
+	m, err := lookup(name)
+	if err != nil {
+		return err
+	}
+	cmd := m.newCommand()
+
+	if !m.snippets {
+		return generators.GenMarkdownTree(cmd, outDir, true)
+	}
+
+	if err := generators.GenMarkdownTree(cmd, outDir, false); err != nil {
+		return fmt.Errorf("failed to generate markdown tree: %w", err)
+	}
+
+	// cleanup generated code for usage as include in the website
+	return generators.MarkdownPostProcessing(cmd, outDir, generators.CleanupForInclude)
+}
+
+// GenerateFormat writes the docs of a module in a format other than the
+// Markdown of the website: man pages ("man"), plain text ("text") or the flag
+// metadata ("yaml" or "json").
+func GenerateFormat(path, name, format string) error {
+	outDir, err := outDir(path)
+	if err != nil {
+		return fmt.Errorf("failed to get output directory: %w", err)
+	}
+
+	cmd, err := NewCommand(name)
+	if err != nil {
+		return err
+	}
+	return generators.GenFormat(cmd, outDir, format)
+}
+
+// outDir returns the absolute path of an existing output directory, with a
+// trailing separator.
+func outDir(path string) (string, error) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	stat, err := os.Stat(dir)
+	if err != nil {
+		return "", err
+	}
+	if !stat.IsDir() {
+		return "", fmt.Errorf("output directory %s is not a directory", dir)
+	}
+	return dir + string(filepath.Separator), nil
+}
+
+// errUsage is returned by run when the arguments are invalid.
+var errUsage = errors.New("invalid arguments")
+
+// Main generates the docs of the module given on the command line.
+func Main() {
+	// use os.Args instead of "flags" because "flags" will mess up the man pages!
+	if err := run(os.Args[1:]); err == errUsage {
+		log.Fatalf("usage: %s [--lang <code>] [--placeholders <file>] <output-dir> <module> [markdown|man|text|yaml|json]", os.Args[0])
+	} else if err != nil {
+		log.Fatalf("failure: %v", err)
+	}
+}
+
+// run generates the docs of a module from the command line arguments.
+func run(cmdArgs []string) error {
+	args, lang, err := parseLang(cmdArgs)
+	if err != nil {
+		return err
+	}
+	args, placeholdersFile, err := cutOption(args, "placeholders")
+	if err != nil {
+		return err
+	}
+	// Some commands, such as kubectl, read os.Args when they are created,
+	// and must not see the options of the generator.
+	os.Args = append(os.Args[:1:1], args...)
+
+	path := ""
+	name := ""
+	format := "markdown"
+	if len(args) == 2 || len(args) == 3 {
+		path = args[0]
+		name = args[1]
+		if len(args) == 3 {
+			format = args[2]
+		}
+	} else {
+		return errUsage
+	}
+
+	// Localized pages go into a directory named after the language, e.g.
+	// "build/ja".
+	if lang != "" {
+		if err := useLanguage(lang, cmdArgs); err != nil {
+			return err
+		}
+		path = filepath.Join(path, lang)
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	if placeholdersFile != "" {
+		if err := generators.LoadPlaceholders(placeholdersFile); err != nil {
+			return err
+		}
+	}
+
+	if format == "markdown" {
+		err = GenerateFiles(path, name)
+	} else {
+		err = GenerateFormat(path, name, format)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Report the flag defaults depending on this machine, which would
+	// otherwise differ between the docs generated on different machines.
+	return generators.WriteDefaultsReport(os.Stderr)
+}
+
+// cutOption removes the "--<name> <value>" or "--<name>=<value>" option from
+// the arguments and returns its value, empty if the option is not given.
+func cutOption(args []string, name string) ([]string, string, error) {
+	var rest []string
+	value := ""
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--"+name:
+			if i+1 == len(args) {
+				return nil, "", fmt.Errorf("--%s requires a value", name)
+			}
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--"+name+"="):
+			value = strings.TrimPrefix(args[i], "--"+name+"=")
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	return rest, value, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gendocs
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-compdocs/generators"
+	"github.com/spf13/cobra"
+)
+
+// registerTestModule registers a module for the duration of the test, whose
+// constructor records os.Args the way kubectl reads them.
+func registerTestModule(t *testing.T, name string, args *[]string, opts ...Option) {
+	t.Helper()
+	Register(name, func() *cobra.Command {
+		*args = append([]string{}, os.Args...)
+		cmd := &cobra.Command{Use: name, Short: "A test command.", Run: func(*cobra.Command, []string) {}}
+		cmd.Flags().String("output", "", "Output format.")
+		cmd.AddCommand(&cobra.Command{Use: "sub", Short: "A subcommand.", Run: func(*cobra.Command, []string) {}})
+		return cmd
+	}, opts...)
+	t.Cleanup(func() { delete(registry, name) })
+}
+
+func TestRunLocalized(t *testing.T) {
+	var args []string
+	registerTestModule(t, "mycli", &args, Snippets())
+
+	// The locale is already set, so the generator is not run again.
+	t.Setenv("LC_ALL", "ja_JP.UTF-8")
+	osArgs := os.Args
+	t.Cleanup(func() {
+		os.Args = osArgs
+		generators.SetLanguage("")
+	})
+	os.Args = []string{"gen-compdocs"}
+
+	dir := t.TempDir()
+	if err := run([]string{"--lang", "ja", dir, "mycli"}); err != nil {
+		t.Fatalf("failed to generate the docs: %v", err)
+	}
+
+	if want := []string{"gen-compdocs", dir, "mycli"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("the command was created with os.Args %q, want %q", args, want)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "ja", "mycli.md"))
+	if err != nil {
+		t.Fatalf("failed to read the generated page: %v", err)
+	}
+	for _, want := range []string{"### 概要", "### オプション"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("the page does not contain %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestParseLang(t *testing.T) {
+	cases := []struct {
+		args     []string
+		wantArgs []string
+		wantLang string
+		wantErr  bool
+	}{
+		{args: []string{"build", "kubectl"}, wantArgs: []string{"build", "kubectl"}},
+		{args: []string{"--lang", "ja", "build", "kubectl"}, wantArgs: []string{"build", "kubectl"}, wantLang: "ja"},
+		{args: []string{"build", "kubectl", "--lang=zh-cn"}, wantArgs: []string{"build", "kubectl"}, wantLang: "zh-cn"},
+		{args: []string{"build", "kubectl", "--lang"}, wantErr: true},
+		{args: []string{"--lang", "xx", "build", "kubectl"}, wantErr: true},
+		// kubectl has a catalog for German, the generator does not.
+		{args: []string{"--lang", "de", "build", "kubectl"}, wantErr: true},
+	}
+	for _, c := range cases {
+		args, lang, err := parseLang(c.args)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("parseLang(%q): expected an error", c.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseLang(%q): %v", c.args, err)
+			continue
+		}
+		if !reflect.DeepEqual(args, c.wantArgs) || lang != c.wantLang {
+			t.Errorf("parseLang(%q) = %q, %q, want %q, %q", c.args, args, lang, c.wantArgs, c.wantLang)
+		}
+	}
+}
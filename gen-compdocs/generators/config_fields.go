@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ConfigField is the field in the configuration file of a component that
+// can be used instead of a flag.
+type ConfigField struct {
+	// Name is the dotted path of the field, e.g. "authentication.x509.clientCAFile".
+	Name string
+	// Link is the page documenting the configuration type.
+	Link string
+}
+
+// configFields maps flag names to the config file fields backing them.
+var configFields map[string]ConfigField
+
+// SetConfigFields sets the config file fields of the flags for the component
+// being documented.
+func SetConfigFields(fields map[string]ConfigField) {
+	configFields = fields
+}
+
+// configFieldUsage returns the note on the config field backing a flag, to be
+// appended to the usage of the flag.
+func configFieldUsage(flag *pflag.Flag) string {
+	field, ok := configFields[flag.Name]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("<br/>%s: <a href=\"%s\"><code>%s</code></a>", tr("Config file field"), field.Link, field.Name)
+}
+
+// printConfigFileFlags lists the flags of a command which are deprecated in
+// favour of the config file, along with the config fields to use instead.
+func printConfigFileFlags(w io.Writer, cmd *cobra.Command, withTitle bool) error {
+	var flags []*pflag.Flag
+	cmd.NonInheritedFlags().VisitAll(func(flag *pflag.Flag) {
+		if _, ok := configFields[flag.Name]; ok && len(flag.Deprecated) > 0 && !flag.Hidden {
+			flags = append(flags, flag)
+		}
+	})
+	if len(flags) == 0 {
+		return nil
+	}
+
+	heading := "## " + tr("Flags deprecated in favour of the config file")
+	if !withTitle {
+		heading = "### " + tr("Flags deprecated in favour of the config file")
+	}
+	if _, err := fmt.Fprintf(w, "%s\n\n", heading); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "| %s | %s |\n|---|---|\n", tr("Flag"), tr("Config file field")); err != nil {
+		return err
+	}
+	for _, flag := range flags {
+		field := configFields[flag.Name]
+		if _, err := fmt.Fprintf(w, "| `--%s` | [`%s`](%s) |\n", flag.Name, field.Name, field.Link); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprint(w, "\n")
+	return err
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+// Placeholder replaces a value specific to the machine generating the docs
+// in the flag defaults, so that the docs are the same on every machine. The
+// value is only replaced where it is not part of a longer name or path
+// component, e.g. a home directory of /root does not match /rootfs.
+type Placeholder struct {
+	// Value is the machine-specific value, e.g. the home directory.
+	Value string `json:"value"`
+	// Placeholder is shown instead of the value, e.g. "$HOME".
+	Placeholder string `json:"placeholder"`
+}
+
+// PlaceholderConfig configures the placeholders, in addition to the ones of
+// HostPlaceholders. It is read from the file given to gen-compdocs with
+// --placeholders.
+type PlaceholderConfig struct {
+	// Placeholders are other values to replace.
+	Placeholders []Placeholder `json:"placeholders,omitempty"`
+	// Flags maps the flags whose whole default is computed on the machine,
+	// such as the number of CPUs, to the placeholder shown instead. The flags
+	// are given as "<command path> --<flag>", e.g. "mycli serve --workers", and
+	// include the subcommands inheriting the flag.
+	Flags map[string]string `json:"flags,omitempty"`
+}
+
+// HostPlaceholders returns the placeholders of the home directory, the
+// temporary directory and the host name of the machine.
+func HostPlaceholders() []Placeholder {
+	var result []Placeholder
+	if home, err := os.UserHomeDir(); err == nil && len(home) > 1 {
+		result = append(result, Placeholder{Value: strings.TrimSuffix(home, "/"), Placeholder: "$HOME"})
+	}
+	if tmp := strings.TrimSuffix(os.TempDir(), "/"); tmp != "/tmp" && len(tmp) > 1 {
+		result = append(result, Placeholder{Value: tmp, Placeholder: "$TMPDIR"})
+	}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		result = append(result, Placeholder{Value: hostname, Placeholder: "<hostname>"})
+		// The kubelet uses the lower-cased host name as node name.
+		if lower := strings.ToLower(hostname); lower != hostname {
+			result = append(result, Placeholder{Value: lower, Placeholder: "<hostname>"})
+		}
+	}
+	return result
+}
+
+var (
+	// placeholders are applied to the defaults of all flags.
+	placeholders = HostPlaceholders()
+	// flagPlaceholders replace the whole defaults of the flags, by
+	// "<command path> --<flag>".
+	flagPlaceholders map[string]string
+)
+
+// SetPlaceholders sets the placeholders applied to the flag defaults.
+func SetPlaceholders(p []Placeholder) {
+	placeholders = p
+}
+
+// SetFlagPlaceholders sets the placeholders replacing the whole defaults of
+// flags, keyed by "<command path> --<flag>".
+func SetFlagPlaceholders(flags map[string]string) {
+	flagPlaceholders = flags
+}
+
+// LoadPlaceholders reads a PlaceholderConfig in YAML or JSON and adds its
+// placeholders to the current ones.
+func LoadPlaceholders(filename string) error {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	var config PlaceholderConfig
+	if err := yaml.UnmarshalStrict(content, &config); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	for _, p := range config.Placeholders {
+		if p.Value == "" || p.Placeholder == "" {
+			return fmt.Errorf("invalid placeholder in %s: the value and the placeholder are required", filename)
+		}
+	}
+	placeholders = append(placeholders, config.Placeholders...)
+	if len(config.Flags) > 0 {
+		if flagPlaceholders == nil {
+			flagPlaceholders = make(map[string]string)
+		}
+		for flag, placeholder := range config.Flags {
+			flagPlaceholders[flag] = placeholder
+		}
+	}
+	return nil
+}
+
+// hostDefault is a flag default which depends on the machine.
+type hostDefault struct {
+	flag       string
+	value      string
+	normalized string
+}
+
+// hostDefaults records the defaults replaced by placeholders or looking
+// host-derived, by flag name and value.
+var hostDefaults = make(map[string]hostDefault)
+
+// hostDerived matches values which look specific to the machine even after
+// the placeholders are applied: paths in home directories, macOS temporary
+// directories and private IPv4 addresses.
+var hostDerived = regexp.MustCompile(`(^|[/\\])(home|Users)[/\\]|/var/folders/|\b(10\.\d+|172\.(1[6-9]|2\d|3[01])|192\.168)\.\d+\.\d+\b`)
+
+// normalizeDefault returns the default value of a flag of a command with the
+// machine-specific values replaced by placeholders.
+func normalizeDefault(cmd *cobra.Command, flag *pflag.Flag) string {
+	value := flag.DefValue
+	if value == "" {
+		return value
+	}
+
+	normalized, ok := flagPlaceholder(cmd, flag)
+	if !ok {
+		// Replace the longest values first, e.g. the home directory before
+		// the host name in it.
+		ordered := append([]Placeholder{}, placeholders...)
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return len(ordered[i].Value) > len(ordered[j].Value)
+		})
+
+		normalized = value
+		for _, p := range ordered {
+			if p.Value != "" {
+				normalized = replaceWhole(normalized, p.Value, p.Placeholder)
+			}
+		}
+	}
+
+	if normalized != value || hostDerived.MatchString(normalized) {
+		hostDefaults[flag.Name+"="+value] = hostDefault{flag: flag.Name, value: value, normalized: normalized}
+	}
+	return normalized
+}
+
+// flagPlaceholder returns the placeholder of the whole default of a flag of
+// the command or inherited from one of its parents.
+func flagPlaceholder(cmd *cobra.Command, flag *pflag.Flag) (string, bool) {
+	for c := cmd; c != nil; c = c.Parent() {
+		if p, ok := flagPlaceholders[c.CommandPath()+" --"+flag.Name]; ok {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// replaceWhole replaces the occurrences of value in s which are not part of
+// a longer name or path component.
+func replaceWhole(s, value, placeholder string) string {
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(s); {
+		j := strings.Index(s[i:], value)
+		if j < 0 {
+			break
+		}
+		j += i
+		end := j + len(value)
+		if (j == 0 || !isNameChar(s[j-1])) && (end == len(s) || !isNameChar(s[end])) {
+			b.WriteString(s[last:j])
+			b.WriteString(placeholder)
+			last = end
+			i = end
+		} else {
+			i = j + 1
+		}
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// isNameChar tests if c can be part of a host name or a file name.
+func isNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.'
+}
+
+// WriteDefaultsReport lists the flag defaults replaced by placeholders and
+// the ones which still look derived from the machine generating the docs.
+// Nothing is written if there are none.
+func WriteDefaultsReport(w io.Writer) error {
+	if len(hostDefaults) == 0 {
+		return nil
+	}
+
+	var replaced, suspicious []hostDefault
+	for _, d := range hostDefaults {
+		if hostDerived.MatchString(d.normalized) {
+			suspicious = append(suspicious, d)
+		} else {
+			replaced = append(replaced, d)
+		}
+	}
+
+	for _, group := range []struct {
+		title    string
+		defaults []hostDefault
+	}{
+		{"Flag defaults replaced by placeholders", replaced},
+		{"Flag defaults which look host-derived", suspicious},
+	} {
+		if len(group.defaults) == 0 {
+			continue
+		}
+		sort.Slice(group.defaults, func(i, j int) bool {
+			if group.defaults[i].flag != group.defaults[j].flag {
+				return group.defaults[i].flag < group.defaults[j].flag
+			}
+			return group.defaults[i].value < group.defaults[j].value
+		})
+		if _, err := fmt.Fprintf(w, "%s:\n", group.title); err != nil {
+			return err
+		}
+		for _, d := range group.defaults {
+			line := fmt.Sprintf("  --%s: %q", d.flag, d.value)
+			if d.normalized != d.value {
+				line += fmt.Sprintf(" -> %q", d.normalized)
+			}
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
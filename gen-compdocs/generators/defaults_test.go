@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// usePlaceholders replaces the placeholders of the machine running the test
+// and the recorded host defaults for the duration of the test.
+func usePlaceholders(t *testing.T, p []Placeholder, flags map[string]string) {
+	t.Helper()
+	oldPlaceholders, oldFlags, oldDefaults := placeholders, flagPlaceholders, hostDefaults
+	t.Cleanup(func() {
+		placeholders, flagPlaceholders, hostDefaults = oldPlaceholders, oldFlags, oldDefaults
+	})
+	placeholders, flagPlaceholders = p, flags
+	hostDefaults = make(map[string]hostDefault)
+}
+
+// newTestCommand returns a "kubectl diff" command, with a persistent flag
+// defined on "kubectl".
+func newTestCommand() *cobra.Command {
+	root := &cobra.Command{Use: "kubectl"}
+	root.PersistentFlags().String("cache-dir", "", "Cache directory.")
+	diff := &cobra.Command{Use: "diff"}
+	root.AddCommand(diff)
+	return diff
+}
+
+func TestNormalizeDefault(t *testing.T) {
+	// A root user on a builder with a single CPU.
+	usePlaceholders(t, []Placeholder{
+		{Value: "/root", Placeholder: "$HOME"},
+		{Value: "node1", Placeholder: "<hostname>"},
+	}, map[string]string{
+		"kubectl diff --parallelism": "<num-cpus>",
+		"kubectl --cache-dir":        "$HOME/.kube/cache",
+	})
+
+	cases := []struct {
+		flag  string
+		value string
+		want  string
+	}{
+		{flag: "kubeconfig", value: "/root/.kube/config", want: "$HOME/.kube/config"},
+		{flag: "root-dir", value: "/root", want: "$HOME"},
+		{flag: "rootfs", value: "/rootfs/var/lib", want: "/rootfs/var/lib"},
+		{flag: "data-dir", value: "/data/root/.kube", want: "/data/root/.kube"},
+		{flag: "storage-driver-user", value: "root", want: "root"},
+		{flag: "hostname-override", value: "node1", want: "<hostname>"},
+		{flag: "address", value: "node1:10250", want: "<hostname>:10250"},
+		{flag: "node-name", value: "node10", want: "node10"},
+		{flag: "domain", value: "node1.example.com", want: "node1.example.com"},
+		{flag: "concurrency", value: "1", want: "1"},
+		{flag: "parallelism", value: "1", want: "<num-cpus>"},
+		{flag: "cache-dir", value: "/root/.kube/cache", want: "$HOME/.kube/cache"},
+	}
+	for _, c := range cases {
+		cmd := newTestCommand()
+		flag := &pflag.Flag{Name: c.flag, DefValue: c.value}
+		if got := normalizeDefault(cmd, flag); got != c.want {
+			t.Errorf("normalizeDefault(--%s=%q) = %q, want %q", c.flag, c.value, got, c.want)
+		}
+	}
+}
+
+func TestWriteDefaultsReport(t *testing.T) {
+	usePlaceholders(t, []Placeholder{{Value: "/root", Placeholder: "$HOME"}}, nil)
+
+	cmd := newTestCommand()
+	for _, flag := range []*pflag.Flag{
+		{Name: "kubeconfig", DefValue: "/root/.kube/config"},
+		{Name: "bind-address", DefValue: "192.168.1.10"},
+		{Name: "storage-driver-user", DefValue: "root"},
+		{Name: "cert-dir", DefValue: "/var/run/certs"},
+	} {
+		normalizeDefault(cmd, flag)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDefaultsReport(&buf); err != nil {
+		t.Fatalf("failed to write the report: %v", err)
+	}
+	want := "Flag defaults replaced by placeholders:\n" +
+		"  --kubeconfig: \"/root/.kube/config\" -> \"$HOME/.kube/config\"\n" +
+		"Flag defaults which look host-derived:\n" +
+		"  --bind-address: \"192.168.1.10\"\n"
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected report\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+}
+
+func TestLoadPlaceholders(t *testing.T) {
+	usePlaceholders(t, []Placeholder{{Value: "/root", Placeholder: "$HOME"}}, nil)
+
+	file := filepath.Join(t.TempDir(), "placeholders.yaml")
+	config := `placeholders:
+- value: /srv/build
+  placeholder: $BUILD_DIR
+flags:
+  kubectl diff --parallelism: <num-cpus>
+`
+	if err := os.WriteFile(file, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadPlaceholders(file); err != nil {
+		t.Fatalf("failed to load the placeholders: %v", err)
+	}
+
+	cmd := newTestCommand()
+	for value, want := range map[string]string{
+		"/root/.kube":     "$HOME/.kube",
+		"/srv/build/logs": "$BUILD_DIR/logs",
+	} {
+		if got := normalizeDefault(cmd, &pflag.Flag{Name: "dir", DefValue: value}); got != want {
+			t.Errorf("normalizeDefault(%q) = %q, want %q", value, got, want)
+		}
+	}
+	if got := normalizeDefault(cmd, &pflag.Flag{Name: "parallelism", DefValue: "4"}); got != "<num-cpus>" {
+		t.Errorf("normalizeDefault(--parallelism) = %q, want <num-cpus>", got)
+	}
+
+	if err := os.WriteFile(file, []byte("placeholders:\n- value: /srv\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadPlaceholders(file); err == nil {
+		t.Errorf("expected an error for a placeholder without a name")
+	}
+}
+
+func TestFlagUsagesEscapesPlaceholders(t *testing.T) {
+	usePlaceholders(t, []Placeholder{{Value: "node1", Placeholder: "<hostname>"}}, nil)
+
+	cmd := &cobra.Command{Use: "kubelet"}
+	cmd.Flags().String("hostname-override", "node1", "The name of the node.")
+	usages := flagUsages(cmd, cmd.Flags())
+	if !strings.Contains(usages, `Default: "&lt;hostname&gt;"`) {
+		t.Errorf("the placeholder is not escaped:\n%s", usages)
+	}
+}
@@ -19,11 +19,10 @@ package generators
 import (
 	"bytes"
 	"fmt"
+	"html"
 	"io"
 	"os"
-	"os/user"
 	"path/filepath"
-	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -54,40 +53,36 @@ guide. You can file document formatting bugs against the
 func GenMarkdownTree(cmd *cobra.Command, dir string, withTitle bool) error {
 	identity := func(s string) string { return s }
 	emptyStr := func(s string) string { return "" }
-	return GenMarkdownTreeCustom(cmd, dir, "", emptyStr, identity, withTitle)
+	return GenMarkdownTreeCustom(cmd, dir, emptyStr, identity, withTitle)
 }
 
-func GenMarkdownTreeCustom(cmd *cobra.Command, dir string, subdir string, filePrepender, linkHandler func(string) string, withTitle bool) error {
-	for _, c := range cmd.Commands() {
-		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
-			continue
-		}
-		// CommandPath example: 'kubectl top pod'
-		parts := strings.Split(c.CommandPath(), " ")
-		subdir := ""
-		if len(parts) > 1 {
-			subdir = parts[0] + "_" + parts[1]
-			fullPath := filepath.Join(dir, subdir)
-			if _, err := os.Stat(fullPath); os.IsNotExist(err) {
-				os.Mkdir(fullPath, 0770)
-			}
-		}
-		if err := GenMarkdownTreeCustom(c, dir, subdir, filePrepender, linkHandler, withTitle); err != nil {
+// GenFormat writes the docs of the command tree in a format other than the
+// Markdown of the website: "man", "text", "yaml" or "json".
+func GenFormat(cmd *cobra.Command, dir string, format string) error {
+	switch format {
+	case "man":
+		return GenManTree(cmd, dir)
+	case "text":
+		return GenTextTree(cmd, dir)
+	case "yaml", "json":
+		return GenFlagData(cmd, dir, format)
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func GenMarkdownTreeCustom(cmd *cobra.Command, dir string, filePrepender, linkHandler func(string) string, withTitle bool) error {
+	for _, c := range availableCommands(cmd) {
+		if err := GenMarkdownTreeCustom(c, dir, filePrepender, linkHandler, withTitle); err != nil {
 			return err
 		}
 	}
 
-	fullname := strings.ReplaceAll(cmd.CommandPath(), " ", "_") + ".md"
-	indexFile := false
-	if len(subdir) > 0 {
-		parts := strings.Split(cmd.CommandPath(), " ")
-		if len(parts) == 2 {
-			indexFile = true
-			fullname = "_index.md"
-		}
-		fullname = filepath.Join(subdir, fullname)
+	indexFile := hierarchy.isIndex(cmd)
+	filename := filepath.Join(dir, filepath.FromSlash(hierarchy.file(cmd)))
+	if err := os.MkdirAll(filepath.Dir(filename), 0770); err != nil {
+		return err
 	}
-	filename := filepath.Join(dir, fullname)
 
 	f, err := os.Create(filename)
 	if err != nil {
@@ -135,6 +130,10 @@ func GenReference(cmd *cobra.Command, w io.Writer, linkHandler func(string) stri
 		// Print the "generated" warning
 		fmt.Fprintf(w, "%s\n\n", generated_warning)
 
+		if err := printBreadcrumbs(w, cmd, linkHandler); err != nil {
+			return err
+		}
+
 		if _, err := fmt.Fprintf(w, "%s\n\n", `## {{% heading "synopsis" %}}`); err != nil {
 			return err
 		}
@@ -151,6 +150,12 @@ func GenReference(cmd *cobra.Command, w io.Writer, linkHandler func(string) stri
 				return err
 			}
 		}
+
+		if indexFile {
+			if err := printSubcommands(w, cmd, linkHandler); err != nil {
+				return err
+			}
+		}
 	} else {
 		// Print the "generated" warning
 		fmt.Fprintf(w, "%s\n\n", generated_warning)
@@ -163,7 +168,7 @@ func GenReference(cmd *cobra.Command, w io.Writer, linkHandler func(string) stri
 			}
 		}
 
-		if _, err := fmt.Fprintf(w, "### Synopsis\n\n"); err != nil {
+		if _, err := fmt.Fprintf(w, "### %s\n\n", tr("Synopsis")); err != nil {
 			return err
 		}
 
@@ -187,18 +192,14 @@ func GenReference(cmd *cobra.Command, w io.Writer, linkHandler func(string) stri
 			if _, err := fmt.Fprintf(w, "%s\n\n", `## {{% heading "examples" %}}`); err != nil {
 				return err
 			}
-
-			if _, err := fmt.Fprintf(w, "```\n%s\n```\n\n", cmd.Example); err != nil {
-				return err
-			}
 		} else {
-			if _, err := fmt.Fprintf(w, "### Examples\n\n"); err != nil {
+			if _, err := fmt.Fprintf(w, "### %s\n\n", tr("Examples")); err != nil {
 				return err
 			}
+		}
 
-			if _, err := fmt.Fprintf(w, "```\n%s\n```\n\n", cmd.Example); err != nil {
-				return err
-			}
+		if err := printExamples(w, cmd.Example, withTitle); err != nil {
+			return err
 		}
 	}
 
@@ -207,6 +208,10 @@ func GenReference(cmd *cobra.Command, w io.Writer, linkHandler func(string) stri
 		return err
 	}
 
+	if err := printConfigFileFlags(w, cmd, withTitle); err != nil {
+		return err
+	}
+
 	/* SEE ALSO */
 	if hasSeeAlso(cmd) {
 		if withTitle {
@@ -214,20 +219,15 @@ func GenReference(cmd *cobra.Command, w io.Writer, linkHandler func(string) stri
 				return err
 			}
 		} else {
-			if _, err := fmt.Fprintf(w, "%s\n\n", "SEE ALSO"); err != nil {
+			if _, err := fmt.Fprintf(w, "%s\n\n", tr("SEE ALSO")); err != nil {
 				return err
 			}
 		}
 
 		if cmd.HasParent() {
 			parent := cmd.Parent()
-			pname := parent.CommandPath()
-			link := "../" + pname + "/"
-			if !indexFile {
-				link = "../"
-			}
-			link = strings.ReplaceAll(link, " ", "_")
-			if _, err := fmt.Fprintf(w, "* [%s](%s)\t - %s\n", pname, linkHandler(link), parent.Short); err != nil {
+			link := hierarchy.link(cmd, parent)
+			if _, err := fmt.Fprintf(w, "* [%s](%s)\t - %s\n", parent.CommandPath(), linkHandler(link), parent.Short); err != nil {
 				return err
 			}
 			cmd.VisitParents(func(c *cobra.Command) {
@@ -237,21 +237,9 @@ func GenReference(cmd *cobra.Command, w io.Writer, linkHandler func(string) stri
 			})
 		}
 
-		children := cmd.Commands()
-		sort.Sort(byName(children))
-
-		for _, child := range children {
-			if !child.IsAvailableCommand() || child.IsAdditionalHelpTopicCommand() {
-				continue
-			}
-			cname := name + " " + child.Name()
-			link := strings.ReplaceAll(cname, " ", "_") + "/"
-			if !indexFile {
-				link = "../" + link
-			}
-
-			// link = strings.ReplaceAll(link, " ", "_")
-			if _, err := fmt.Fprintf(w, "* [%s](%s)\t - %s\n", cname, linkHandler(link), child.Short); err != nil {
+		for _, child := range availableCommands(cmd) {
+			link := hierarchy.link(cmd, child)
+			if _, err := fmt.Fprintf(w, "* [%s](%s)\t - %s\n", child.CommandPath(), linkHandler(link), child.Short); err != nil {
 				return err
 			}
 		}
@@ -274,12 +262,18 @@ func printOptions(w io.Writer, cmd *cobra.Command, name string, withTitle bool)
 				return err
 			}
 		} else {
-			if _, err := fmt.Fprint(w, "### Options\n\n"); err != nil {
+			if _, err := fmt.Fprintf(w, "### %s\n\n", tr("Options")); err != nil {
 				return err
 			}
 		}
-		usages := flagUsages(flags)
-		fmt.Fprint(w, usages)
+		if sections := flagSections(cmd, flags); sections != nil {
+			if err := printFlagSections(w, cmd, sections, withTitle); err != nil {
+				return err
+			}
+		} else {
+			usages := flagUsages(cmd, flags)
+			fmt.Fprint(w, usages)
+		}
 		if _, err := fmt.Fprint(w, "\n"); err != nil {
 			return err
 		}
@@ -294,11 +288,11 @@ func printOptions(w io.Writer, cmd *cobra.Command, name string, withTitle bool)
 				return err
 			}
 		} else {
-			if _, err := fmt.Fprint(w, "### Options inherited from parent commands\n\n"); err != nil {
+			if _, err := fmt.Fprintf(w, "### %s\n\n", tr("Options inherited from parent commands")); err != nil {
 				return err
 			}
 		}
-		usages := flagUsages(parentFlags)
+		usages := flagUsages(cmd, parentFlags)
 		fmt.Fprint(w, usages)
 
 		if _, err := fmt.Fprint(w, "\n"); err != nil {
@@ -321,7 +315,7 @@ func hasSeeAlso(cmd *cobra.Command) bool {
 	return false
 }
 
-func flagUsages(f *pflag.FlagSet) string {
+func flagUsages(cmd *cobra.Command, f *pflag.FlagSet) string {
 	x := new(bytes.Buffer)
 
 	lines := make([]string, 0)
@@ -362,24 +356,16 @@ func flagUsages(f *pflag.FlagSet) string {
 			}
 		}
 		if !defaultIsZeroValue(flag) {
-			defaultValue := flag.DefValue
+			// Placeholders such as <hostname> must not be parsed as tags.
+			defaultValue := html.EscapeString(normalizeDefault(cmd, flag))
 			if flag.Value.Type() == "string" {
 				// There are cases where the string is very very long, split
 				// it to mutiple lines manually
 				if len(defaultValue) > 40 {
 					defaultValue = strings.ReplaceAll(defaultValue, ",", ",<br />")
 				}
-				// clean up kubectl cache-dir flag value
-				if strings.Compare(flag.Name, "cache-dir") == 0 {
-					myUser, err := user.Current()
-					if err == nil {
-						noprefix := strings.TrimPrefix(defaultValue, myUser.HomeDir)
-						defaultValue = fmt.Sprintf("$HOME%s", noprefix)
-					}
-				}
 				line += fmt.Sprintf("&nbsp;&nbsp;&nbsp;&nbsp;&nbsp;Default: \"%s\"", defaultValue)
 			} else if flag.Value.Type() == "stringSlice" {
-				defaultValue := flag.DefValue
 				// For string slices, the default value should not contain '[' ]r ']'
 				defaultValue = strings.TrimPrefix(defaultValue, "[")
 				defaultValue = strings.TrimSuffix(defaultValue, "]")
@@ -395,7 +381,7 @@ func flagUsages(f *pflag.FlagSet) string {
 		if len(flag.Deprecated) > 0 {
 			usage = usage + " (DEPRECATED: " + flag.Deprecated + ")"
 		}
-		line += processUsage(usage) + "</td>\n</tr>\n"
+		line += processUsage(usage) + configFieldUsage(flag) + "</td>\n</tr>\n"
 
 		lines = append(lines, line)
 	})
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// example is a command from the examples of a command, along with the
+// comment describing it.
+type example struct {
+	Title   string
+	Command string
+	Anchor  string
+}
+
+// parseExamples splits the examples of a command into comment and command
+// pairs, the same way as FormatExample in gen-kubectldocs: consecutive
+// comment lines are joined into the title of the example and the command
+// lines following them form its command. The common indentation of the
+// command lines is removed, the rest is kept for multi-line commands.
+func parseExamples(text string) []example {
+	var result []example
+	var commands []string
+	var title string
+	lastComment := false
+
+	flush := func() {
+		if len(commands) > 0 || title != "" {
+			result = append(result, example{Title: title, Command: strings.Join(dedent(commands), "\n")})
+		}
+		commands = nil
+		title = ""
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			comment := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+			if comment == "" {
+				continue
+			}
+			if lastComment {
+				title += " " + comment
+			} else {
+				flush()
+				title = comment
+			}
+			lastComment = true
+			continue
+		}
+		commands = append(commands, strings.TrimRight(line, " \t"))
+		lastComment = false
+	}
+	flush()
+
+	seen := make(map[string]int)
+	for i := range result {
+		anchor := strings.Trim(nonAlphaNum.ReplaceAllString(strings.ToLower(result[i].Title), "-"), "-")
+		if anchor == "" {
+			anchor = fmt.Sprintf("%d", i+1)
+		}
+		anchor = "example-" + anchor
+		seen[anchor]++
+		if n := seen[anchor]; n > 1 {
+			anchor = fmt.Sprintf("%s-%d", anchor, n)
+		}
+		result[i].Anchor = anchor
+	}
+	return result
+}
+
+// dedent removes the indentation shared by all lines.
+func dedent(lines []string) []string {
+	prefix := -1
+	for _, line := range lines {
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+		if prefix < 0 || n < prefix {
+			prefix = n
+		}
+	}
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		result[i] = line[prefix:]
+	}
+	return result
+}
+
+// printExamples prints each example as a titled snippet with its own anchor.
+// Examples without any comment are printed as a single block.
+func printExamples(w io.Writer, text string, withTitle bool) error {
+	examples := parseExamples(text)
+	titled := false
+	for _, e := range examples {
+		if e.Title != "" {
+			titled = true
+		}
+	}
+	if !titled {
+		_, err := fmt.Fprintf(w, "```\n%s\n```\n\n", text)
+		return err
+	}
+
+	level := "###"
+	if !withTitle {
+		level = "####"
+	}
+	for _, e := range examples {
+		title := e.Title
+		if title == "" {
+			title = tr("Example")
+		}
+		title = strings.ReplaceAll(title, "<", "&lt;")
+		title = strings.ReplaceAll(title, ">", "&gt;")
+		if _, err := fmt.Fprintf(w, "%s %s {#%s}\n\n", level, title, e.Anchor); err != nil {
+			return err
+		}
+		if e.Command == "" {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "```shell\n%s\n```\n\n", e.Command); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+)
+
+// FlagDiff is the difference between the flags of a component in two
+// releases.
+type FlagDiff struct {
+	Component string
+
+	AddedCommands   []string
+	RemovedCommands []string
+
+	Added          []FlagChange
+	Removed        []FlagChange
+	Deprecated     []FlagChange
+	DefaultChanged []FlagChange
+}
+
+// FlagChange is a change to a single flag of a command.
+type FlagChange struct {
+	Command string
+	Flag    string
+	// Old and New are the default values, or the deprecation message for
+	// newly deprecated flags.
+	Old string
+	New string
+}
+
+// LoadFlagData reads a flag dump written by GenFlagData, in YAML or JSON.
+func LoadFlagData(filename string) (*FlagData, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var data FlagData
+	if err := yaml.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	return &data, nil
+}
+
+// DiffFlagData compares the flags of each command in the previous and the
+// current flag dumps. Only the flags defined by a command are compared, the
+// inherited ones are reported for the parent command.
+func DiffFlagData(prev, cur *FlagData) *FlagDiff {
+	diff := &FlagDiff{Component: cur.Component}
+
+	prevCmds := make(map[string]CommandData)
+	for _, c := range prev.Commands {
+		prevCmds[c.Path] = c
+	}
+	curCmds := make(map[string]CommandData)
+	for _, c := range cur.Commands {
+		curCmds[c.Path] = c
+	}
+
+	for _, c := range prev.Commands {
+		if _, ok := curCmds[c.Path]; !ok {
+			diff.RemovedCommands = append(diff.RemovedCommands, c.Path)
+		}
+	}
+
+	for _, c := range cur.Commands {
+		p, ok := prevCmds[c.Path]
+		if !ok {
+			diff.AddedCommands = append(diff.AddedCommands, c.Path)
+			continue
+		}
+
+		prevFlags := make(map[string]FlagInfo)
+		for _, f := range p.Flags {
+			prevFlags[f.Name] = f
+		}
+		curFlags := make(map[string]FlagInfo)
+		for _, f := range c.Flags {
+			curFlags[f.Name] = f
+		}
+
+		for _, f := range p.Flags {
+			if _, ok := curFlags[f.Name]; !ok {
+				diff.Removed = append(diff.Removed, FlagChange{Command: c.Path, Flag: f.Name, Old: f.Default})
+			}
+		}
+		for _, f := range c.Flags {
+			pf, ok := prevFlags[f.Name]
+			if !ok {
+				diff.Added = append(diff.Added, FlagChange{Command: c.Path, Flag: f.Name, New: f.Default})
+				continue
+			}
+			if pf.Deprecated == "" && f.Deprecated != "" {
+				diff.Deprecated = append(diff.Deprecated, FlagChange{Command: c.Path, Flag: f.Name, New: f.Deprecated})
+			}
+			if pf.Default != f.Default {
+				diff.DefaultChanged = append(diff.DefaultChanged, FlagChange{Command: c.Path, Flag: f.Name, Old: pf.Default, New: f.Default})
+			}
+		}
+	}
+
+	sort.Strings(diff.AddedCommands)
+	sort.Strings(diff.RemovedCommands)
+	for _, changes := range [][]FlagChange{diff.Added, diff.Removed, diff.Deprecated, diff.DefaultChanged} {
+		sort.Slice(changes, func(i, j int) bool {
+			if changes[i].Command != changes[j].Command {
+				return changes[i].Command < changes[j].Command
+			}
+			return changes[i].Flag < changes[j].Flag
+		})
+	}
+	return diff
+}
+
+// IsEmpty tests if there is no change at all.
+func (d *FlagDiff) IsEmpty() bool {
+	return len(d.AddedCommands) == 0 && len(d.RemovedCommands) == 0 &&
+		len(d.Added) == 0 && len(d.Removed) == 0 &&
+		len(d.Deprecated) == 0 && len(d.DefaultChanged) == 0
+}
+
+// WriteMarkdown writes the diff as a Markdown changelog section.
+func (d *FlagDiff) WriteMarkdown(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "## %s\n\n", d.Component); err != nil {
+		return err
+	}
+	if d.IsEmpty() {
+		_, err := fmt.Fprintf(w, "No changes.\n\n")
+		return err
+	}
+
+	sections := []struct {
+		title string
+		items []string
+	}{
+		{"Removed commands", commandItems(d.RemovedCommands)},
+		{"Added commands", commandItems(d.AddedCommands)},
+		{"Removed flags", changeItems(d.Removed, func(c FlagChange) string {
+			return "default: " + codeValue(c.Old)
+		})},
+		{"Newly deprecated flags", changeItems(d.Deprecated, func(c FlagChange) string {
+			return c.New
+		})},
+		{"Changed defaults", changeItems(d.DefaultChanged, func(c FlagChange) string {
+			return codeValue(c.Old) + " → " + codeValue(c.New)
+		})},
+		{"Added flags", changeItems(d.Added, func(c FlagChange) string {
+			return "default: " + codeValue(c.New)
+		})},
+	}
+
+	for _, s := range sections {
+		if len(s.items) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "### %s\n\n", s.title); err != nil {
+			return err
+		}
+		for _, item := range s.items {
+			if _, err := fmt.Fprintf(w, "- %s\n", item); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprint(w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func commandItems(cmds []string) []string {
+	var items []string
+	for _, c := range cmds {
+		items = append(items, fmt.Sprintf("`%s`", c))
+	}
+	return items
+}
+
+func changeItems(changes []FlagChange, detail func(FlagChange) string) []string {
+	var items []string
+	for _, c := range changes {
+		items = append(items, fmt.Sprintf("`%s --%s` (%s)", c.Command, c.Flag, detail(c)))
+	}
+	return items
+}
+
+// codeValue formats a flag value as inline code, showing empty values as "".
+func codeValue(v string) string {
+	if v == "" {
+		v = `""`
+	}
+	return "`" + v + "`"
+}
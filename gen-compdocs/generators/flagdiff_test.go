@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDiffFlagData(t *testing.T) {
+	prev := &FlagData{
+		Component: "kubeadm",
+		Commands: []CommandData{
+			{
+				Path:  "kubeadm",
+				Flags: []FlagInfo{{Name: "v", Default: "0"}},
+			},
+			{
+				Path: "kubeadm init",
+				Flags: []FlagInfo{
+					{Name: "apiserver-advertise-address", Default: "0.0.0.0"},
+					{Name: "dry-run", Default: "false"},
+					{Name: "experimental-upload-certs", Default: "false", Deprecated: "use --upload-certs instead"},
+					{Name: "node-name", Default: ""},
+					{Name: "skip-phases", Default: ""},
+				},
+				InheritedFlags: []FlagInfo{{Name: "v", Default: "0"}},
+			},
+			{
+				Path:           "kubeadm reset",
+				Flags:          []FlagInfo{{Name: "force", Default: "false"}},
+				InheritedFlags: []FlagInfo{{Name: "v", Default: "0"}},
+			},
+		},
+	}
+
+	cases := []struct {
+		name string
+		cur  *FlagData
+		want string
+	}{
+		{
+			name: "no changes",
+			cur:  prev,
+			want: "## kubeadm\n\nNo changes.\n\n",
+		},
+		{
+			name: "inherited flags",
+			cur: &FlagData{
+				Component: "kubeadm",
+				Commands: []CommandData{
+					prev.Commands[0],
+					{
+						Path:           "kubeadm init",
+						Flags:          prev.Commands[1].Flags,
+						InheritedFlags: []FlagInfo{{Name: "v", Default: "2"}, {Name: "rootfs", Default: ""}},
+					},
+					prev.Commands[2],
+				},
+			},
+			want: "## kubeadm\n\nNo changes.\n\n",
+		},
+		{
+			name: "all changes",
+			cur: &FlagData{
+				Component: "kubeadm",
+				Commands: []CommandData{
+					{
+						Path: "kubeadm init",
+						Flags: []FlagInfo{
+							{Name: "upload-certs", Default: "false"},
+							{Name: "patches", Default: ""},
+							{Name: "apiserver-advertise-address", Default: ""},
+							{Name: "dry-run", Default: "false", Deprecated: "it has no effect"},
+							{Name: "experimental-upload-certs", Default: "false", Deprecated: "it will be removed"},
+							{Name: "node-name", Default: ""},
+						},
+						InheritedFlags: []FlagInfo{{Name: "quiet", Default: "false"}, {Name: "v", Default: "2"}},
+					},
+					{
+						Path:  "kubeadm",
+						Flags: []FlagInfo{{Name: "quiet", Default: "false"}, {Name: "v", Default: "2"}},
+					},
+					{
+						Path:           "kubeadm upgrade",
+						Flags:          []FlagInfo{{Name: "yes", Default: "false"}},
+						InheritedFlags: []FlagInfo{{Name: "quiet", Default: "false"}, {Name: "v", Default: "2"}},
+					},
+				},
+			},
+			want: "## kubeadm\n\n" +
+				"### Removed commands\n\n" +
+				"- `kubeadm reset`\n\n" +
+				"### Added commands\n\n" +
+				"- `kubeadm upgrade`\n\n" +
+				"### Removed flags\n\n" +
+				"- `kubeadm init --skip-phases` (default: `\"\"`)\n\n" +
+				"### Newly deprecated flags\n\n" +
+				"- `kubeadm init --dry-run` (it has no effect)\n\n" +
+				"### Changed defaults\n\n" +
+				"- `kubeadm --v` (`0` → `2`)\n" +
+				"- `kubeadm init --apiserver-advertise-address` (`0.0.0.0` → `\"\"`)\n\n" +
+				"### Added flags\n\n" +
+				"- `kubeadm --quiet` (default: `false`)\n" +
+				"- `kubeadm init --patches` (default: `\"\"`)\n" +
+				"- `kubeadm init --upload-certs` (default: `false`)\n\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := DiffFlagData(prev, c.cur).WriteMarkdown(&buf); err != nil {
+				t.Fatalf("failed to write the diff: %v", err)
+			}
+			if got := buf.String(); got != c.want {
+				t.Errorf("unexpected diff\n--- got ---\n%s\n--- want ---\n%s", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+// FlagData is the flag metadata of a component and all its subcommands.
+type FlagData struct {
+	Component string        `json:"component"`
+	Commands  []CommandData `json:"commands"`
+}
+
+// CommandData describes a command and the flags it accepts.
+type CommandData struct {
+	// Path is the full command path, e.g. "kubeadm init phase preflight".
+	Path     string `json:"path"`
+	Short    string `json:"short,omitempty"`
+	Synopsis string `json:"synopsis,omitempty"`
+	Usage    string `json:"usage,omitempty"`
+	Examples string `json:"examples,omitempty"`
+
+	Flags          []FlagInfo `json:"flags,omitempty"`
+	InheritedFlags []FlagInfo `json:"inheritedFlags,omitempty"`
+}
+
+// FlagInfo is the metadata of a single flag.
+type FlagInfo struct {
+	Name                string `json:"name"`
+	Shorthand           string `json:"shorthand,omitempty"`
+	Type                string `json:"type"`
+	Default             string `json:"default"`
+	NoOptDefault        string `json:"noOptDefault,omitempty"`
+	Deprecated          string `json:"deprecated,omitempty"`
+	ShorthandDeprecated string `json:"shorthandDeprecated,omitempty"`
+	Hidden              bool   `json:"hidden,omitempty"`
+	Usage               string `json:"usage"`
+}
+
+// GenFlagData writes the flag metadata of the command tree into a single
+// file named after the command, in the given format ("yaml" or "json").
+func GenFlagData(cmd *cobra.Command, dir string, format string) error {
+	data := FlagData{
+		Component: cmd.Name(),
+		Commands:  collectCommands(cmd, nil),
+	}
+	sort.Slice(data.Commands, func(i, j int) bool {
+		return data.Commands[i].Path < data.Commands[j].Path
+	})
+
+	var out []byte
+	var err error
+	switch format {
+	case "yaml":
+		out, err = yaml.Marshal(data)
+	case "json":
+		out, err = json.MarshalIndent(data, "", "  ")
+		out = append(out, '\n')
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal flag data: %w", err)
+	}
+
+	filename := filepath.Join(dir, cmd.Name()+"."+format)
+	return os.WriteFile(filename, out, 0644)
+}
+
+// collectCommands appends the data of the command and its subcommands. The
+// same commands as the Markdown pages are included.
+func collectCommands(cmd *cobra.Command, result []CommandData) []CommandData {
+	cmd.InitDefaultHelpCmd()
+	cmd.InitDefaultHelpFlag()
+
+	long := cmd.Long
+	if len(long) == 0 {
+		long = cmd.Short
+	}
+	c := CommandData{
+		Path:           cmd.CommandPath(),
+		Short:          cmd.Short,
+		Synopsis:       long,
+		Examples:       cmd.Example,
+		Flags:          flagInfos(cmd, cmd.NonInheritedFlags()),
+		InheritedFlags: flagInfos(cmd, cmd.InheritedFlags()),
+	}
+	if cmd.Runnable() {
+		c.Usage = cmd.UseLine()
+	}
+	result = append(result, c)
+
+	for _, child := range cmd.Commands() {
+		if !child.IsAvailableCommand() || child.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		result = collectCommands(child, result)
+	}
+	return result
+}
+
+// flagInfos returns the metadata of all flags in a flag set, hidden ones
+// included, sorted by name.
+func flagInfos(cmd *cobra.Command, f *pflag.FlagSet) []FlagInfo {
+	var result []FlagInfo
+	f.VisitAll(func(flag *pflag.Flag) {
+		result = append(result, FlagInfo{
+			Name:                flag.Name,
+			Shorthand:           flag.Shorthand,
+			Type:                flag.Value.Type(),
+			Default:             normalizeDefault(cmd, flag),
+			NoOptDefault:        flag.NoOptDefVal,
+			Deprecated:          flag.Deprecated,
+			ShorthandDeprecated: flag.ShorthandDeprecated,
+			Hidden:              flag.Hidden,
+			Usage:               flag.Usage,
+		})
+	})
+	return result
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Hierarchy configures where the Markdown pages of the commands are written.
+//
+// A command with its own directory is documented by the _index.md page of
+// the directory, and its subcommands are written into the directory. Other
+// commands are documented by a page named after the command path, e.g.
+// "kubectl_config_set-context.md". The directories are named after the
+// command path as well and are nested, e.g.
+// "kubeadm_init/kubeadm_init_phase/kubeadm_init_phase_control-plane/".
+type Hierarchy struct {
+	// DirDepth is the depth up to which every command gets its own
+	// directory, whether it has subcommands or not. The root command has
+	// depth 0 and its page is always written next to the directories.
+	DirDepth int
+	// MaxDepth is the depth of the most deeply nested directories. Commands
+	// below it are written into the directory of their ancestor at MaxDepth.
+	// 0 means no limit.
+	MaxDepth int
+}
+
+// DefaultHierarchy gives every top-level command a directory, as the website
+// expects, and a directory to the commands with subcommands at any depth.
+var DefaultHierarchy = Hierarchy{DirDepth: 1}
+
+// hierarchy is the layout of the pages being generated.
+var hierarchy = DefaultHierarchy
+
+// SetHierarchy sets the layout of the Markdown pages.
+func SetHierarchy(h Hierarchy) {
+	hierarchy = h
+}
+
+func depth(cmd *cobra.Command) int {
+	return len(strings.Fields(cmd.CommandPath())) - 1
+}
+
+// availableCommands returns the subcommands which get a page, sorted by name.
+func availableCommands(cmd *cobra.Command) []*cobra.Command {
+	var result []*cobra.Command
+	for _, c := range cmd.Commands() {
+		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		result = append(result, c)
+	}
+	sort.Sort(byName(result))
+	return result
+}
+
+// isIndex tests if the command gets its own directory, documented by an
+// _index.md page.
+func (h Hierarchy) isIndex(cmd *cobra.Command) bool {
+	d := depth(cmd)
+	if d == 0 || (h.MaxDepth > 0 && d > h.MaxDepth) {
+		return false
+	}
+	return d <= h.DirDepth || len(availableCommands(cmd)) > 0
+}
+
+// dir returns the directory the page of the command is written into.
+func (h Hierarchy) dir(cmd *cobra.Command) string {
+	parent := ""
+	if cmd.HasParent() {
+		parent = h.dir(cmd.Parent())
+	}
+	if h.isIndex(cmd) {
+		return path.Join(parent, baseName(cmd))
+	}
+	return parent
+}
+
+// file returns the path of the page of the command, relative to the output
+// directory.
+func (h Hierarchy) file(cmd *cobra.Command) string {
+	if h.isIndex(cmd) {
+		return path.Join(h.dir(cmd), "_index.md")
+	}
+	return path.Join(h.dir(cmd), baseName(cmd)+".md")
+}
+
+// url returns the path of the page of the command on the website, relative
+// to the page of the root command's parent section.
+func (h Hierarchy) url(cmd *cobra.Command) string {
+	if h.isIndex(cmd) {
+		return h.dir(cmd)
+	}
+	return path.Join(h.dir(cmd), baseName(cmd))
+}
+
+// link returns the relative link from the page of a command to the page of
+// another one.
+func (h Hierarchy) link(from, to *cobra.Command) string {
+	rel := relPath(h.url(from), h.url(to))
+	return rel + "/"
+}
+
+func baseName(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "_")
+}
+
+// relPath returns the path of target relative to base, both being
+// slash-separated relative paths.
+func relPath(base, target string) string {
+	b := strings.Split(base, "/")
+	t := strings.Split(target, "/")
+	i := 0
+	for i < len(b) && i < len(t) && b[i] == t[i] {
+		i++
+	}
+	parts := make([]string, 0, len(b)-i+len(t)-i)
+	for range b[i:] {
+		parts = append(parts, "..")
+	}
+	parts = append(parts, t[i:]...)
+	if len(parts) == 0 {
+		return "."
+	}
+	return strings.Join(parts, "/")
+}
+
+// printBreadcrumbs prints the links to the ancestors of the command.
+func printBreadcrumbs(w io.Writer, cmd *cobra.Command, linkHandler func(string) string) error {
+	if !cmd.HasParent() {
+		return nil
+	}
+	var crumbs []string
+	for c := cmd.Parent(); c != nil; c = c.Parent() {
+		crumbs = append([]string{fmt.Sprintf("[%s](%s)", c.Name(), linkHandler(hierarchy.link(cmd, c)))}, crumbs...)
+	}
+	crumbs = append(crumbs, cmd.Name())
+	_, err := fmt.Fprintf(w, "%s\n\n", strings.Join(crumbs, " > "))
+	return err
+}
+
+// printSubcommands prints a table of the subcommands with their summaries.
+func printSubcommands(w io.Writer, cmd *cobra.Command, linkHandler func(string) string) error {
+	children := availableCommands(cmd)
+	if len(children) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintf(w, "## %s\n\n| %s | %s |\n|---|---|\n", tr("Subcommands"), tr("Command"), tr("Description")); err != nil {
+		return err
+	}
+	for _, c := range children {
+		short := strings.ReplaceAll(c.Short, "|", "\\|")
+		if _, err := fmt.Fprintf(w, "| [%s](%s) | %s |\n", c.CommandPath(), linkHandler(hierarchy.link(cmd, c)), short); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprint(w, "\n")
+	return err
+}
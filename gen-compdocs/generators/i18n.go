@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+// The headings printed with the Hugo "heading" shortcode are localized by
+// the website. The other strings added by the generator are translated with
+// the catalogs below, keyed by the language code of the website.
+var catalogs = map[string]map[string]string{
+	"ja": {
+		"Synopsis":                               "概要",
+		"Options":                                "オプション",
+		"Options inherited from parent commands": "親コマンドから継承したオプション",
+		"Examples":                               "例",
+		"Example":                                "例",
+		"SEE ALSO":                               "関連項目",
+		"Subcommands":                            "サブコマンド",
+		"Command":                                "コマンド",
+		"Description":                            "説明",
+		"Flags deprecated in favour of the config file": "設定ファイルの使用を推奨するため非推奨となったフラグ",
+		"Flag":              "フラグ",
+		"Config file field": "設定ファイルのフィールド",
+	},
+	"ko": {
+		"Synopsis":                               "개요",
+		"Options":                                "옵션",
+		"Options inherited from parent commands": "상위 커맨드에서 상속된 옵션",
+		"Examples":                               "예시",
+		"Example":                                "예시",
+		"SEE ALSO":                               "참고",
+		"Subcommands":                            "하위 커맨드",
+		"Command":                                "커맨드",
+		"Description":                            "설명",
+		"Flags deprecated in favour of the config file": "설정 파일 사용을 권장하여 사용 중단된 플래그",
+		"Flag":              "플래그",
+		"Config file field": "설정 파일 필드",
+	},
+	"zh-cn": {
+		"Synopsis":                               "概要",
+		"Options":                                "选项",
+		"Options inherited from parent commands": "从父命令继承的选项",
+		"Examples":                               "示例",
+		"Example":                                "示例",
+		"SEE ALSO":                               "另请参见",
+		"Subcommands":                            "子命令",
+		"Command":                                "命令",
+		"Description":                            "描述",
+		"Flags deprecated in favour of the config file": "已弃用并由配置文件取代的参数",
+		"Flag":              "参数",
+		"Config file field": "配置文件字段",
+	},
+}
+
+// language is the language code of the pages being generated, empty for
+// English.
+var language string
+
+// SetLanguage sets the language of the strings added by the generator.
+// Strings without a translation in the language are kept in English.
+func SetLanguage(lang string) {
+	language = lang
+}
+
+// HasCatalog tests if the strings added by the generator are translated into
+// the language.
+func HasCatalog(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// tr translates a string added by the generator into the language of the
+// pages.
+func tr(s string) string {
+	if t, ok := catalogs[language][s]; ok {
+		return t
+	}
+	return s
+}
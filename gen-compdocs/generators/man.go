@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// GenManTree writes a man page in section 1 for the command and each of its
+// subcommands, e.g. "kubeadm-init-phase.1".
+func GenManTree(cmd *cobra.Command, dir string) error {
+	for _, c := range cmd.Commands() {
+		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		if err := GenManTree(c, dir); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	genMan(cmd, &buf)
+	filename := filepath.Join(dir, manName(cmd)+".1")
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
+func manName(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+}
+
+func genMan(cmd *cobra.Command, buf *bytes.Buffer) {
+	cmd.InitDefaultHelpCmd()
+	cmd.InitDefaultHelpFlag()
+
+	name := manName(cmd)
+	long := cmd.Long
+	if len(long) == 0 {
+		long = cmd.Short
+	}
+
+	fmt.Fprintf(buf, ".TH %q \"1\" \"\" \"Kubernetes\" \"Kubernetes Manual\"\n", strings.ToUpper(name))
+	fmt.Fprintf(buf, ".SH NAME\n%s \\- %s\n", roffEscape(name), roffEscape(cmd.Short))
+
+	fmt.Fprintf(buf, ".SH SYNOPSIS\n")
+	if cmd.Runnable() {
+		fmt.Fprintf(buf, ".B %s\n", roffEscape(cmd.UseLine()))
+	} else {
+		fmt.Fprintf(buf, ".B %s\n", roffEscape(cmd.CommandPath()+" [command]"))
+	}
+
+	fmt.Fprintf(buf, ".SH DESCRIPTION\n%s\n", roffText(long))
+
+	manOptions(buf, cmd, "OPTIONS", cmd.NonInheritedFlags())
+	manOptions(buf, cmd, "OPTIONS INHERITED FROM PARENT COMMANDS", cmd.InheritedFlags())
+
+	if len(cmd.Example) > 0 {
+		fmt.Fprintf(buf, ".SH EXAMPLES\n.PP\n.RS\n.nf\n%s\n.fi\n.RE\n", roffText(cmd.Example))
+	}
+
+	if hasSeeAlso(cmd) {
+		var refs []string
+		if cmd.HasParent() {
+			refs = append(refs, fmt.Sprintf("\\fB%s\\fP(1)", roffEscape(manName(cmd.Parent()))))
+		}
+		children := cmd.Commands()
+		sort.Sort(byName(children))
+		for _, c := range children {
+			if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
+				continue
+			}
+			refs = append(refs, fmt.Sprintf("\\fB%s\\fP(1)", roffEscape(manName(c))))
+		}
+		fmt.Fprintf(buf, ".SH SEE ALSO\n%s\n", strings.Join(refs, ", "))
+	}
+}
+
+func manOptions(buf *bytes.Buffer, cmd *cobra.Command, title string, flags *pflag.FlagSet) {
+	if !hasVisibleFlags(flags) {
+		return
+	}
+	fmt.Fprintf(buf, ".SH %s\n", title)
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+		varname, usage := unquoteUsage(flag)
+		varname = html.UnescapeString(varname)
+
+		line := "\\fB" + roffEscape("--"+flag.Name) + "\\fP"
+		if len(flag.Shorthand) > 0 && len(flag.ShorthandDeprecated) == 0 {
+			line = "\\fB" + roffEscape("-"+flag.Shorthand) + "\\fP, " + line
+		}
+		if len(varname) > 0 {
+			line += " \\fI" + roffEscape(varname) + "\\fP"
+		}
+		if !defaultIsZeroValue(flag) {
+			line += fmt.Sprintf(" (default %s)", roffEscape(normalizeDefault(cmd, flag)))
+		}
+		if len(flag.Deprecated) > 0 {
+			usage += " (DEPRECATED: " + flag.Deprecated + ")"
+		}
+		fmt.Fprintf(buf, ".TP\n%s\n%s\n", line, roffText(usage))
+	})
+}
+
+// roffEscape escapes a string used on a single line of a man page.
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\e")
+	return strings.ReplaceAll(s, "-", "\\-")
+}
+
+// roffText escapes a block of text, keeping the line breaks. Lines that
+// would be taken as roff requests are protected and empty lines become
+// paragraph breaks.
+func roffText(s string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, line := range lines {
+		line = roffEscape(line)
+		if strings.TrimSpace(line) == "" {
+			line = ".sp"
+		} else if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			line = "\\&" + line
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
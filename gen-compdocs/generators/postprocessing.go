@@ -26,38 +26,19 @@ import (
 )
 
 // MarkdownPostProcessing goes though the generated files
-func MarkdownPostProcessing(cmd *cobra.Command, dir string, subdir string, processor func(string) string) error {
+func MarkdownPostProcessing(cmd *cobra.Command, dir string, processor func(string) string) error {
 	for _, c := range cmd.Commands() {
 		// if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() { // Qiming
 		if !c.IsAvailableCommand() { // Qiming
 			continue
 		}
 
-		parts := strings.Split(c.CommandPath(), " ")
-		subdir := ""
-		if len(parts) > 1 {
-			subdir = parts[0] + "_" + parts[1]
-		}
-
-		if err := MarkdownPostProcessing(c, dir, subdir, processor); err != nil {
+		if err := MarkdownPostProcessing(c, dir, processor); err != nil {
 			return err
 		}
 	}
 
-	// basename := strings.ReplaceAll(cmd.CommandPath(), " ", "_") + ".md"
-	// filename := filepath.Join(dir, basename)
-
-	fullname := strings.ReplaceAll(cmd.CommandPath(), " ", "_") + ".md"
-	// indexFile := false
-	if len(subdir) > 0 {
-		parts := strings.Split(cmd.CommandPath(), " ")
-		if len(parts) == 2 {
-			// indexFile = true
-			fullname = "_index.md"
-		}
-		fullname = filepath.Join(subdir, fullname)
-	}
-	filename := filepath.Join(dir, fullname)
+	filename := filepath.Join(dir, filepath.FromSlash(hierarchy.file(cmd)))
 
 	markdownBytes, err := os.ReadFile(filename)
 	if err != nil {
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// flagSection is a group of flags, as defined by a cliflag.NamedFlagSets.
+type flagSection struct {
+	Name   string
+	Anchor string
+	Flags  *pflag.FlagSet
+}
+
+var (
+	// sectionHeading matches the section headings printed by
+	// cliflag.PrintSections, e.g. "Generic flags:".
+	sectionHeading = regexp.MustCompile(`^(\S.*) flags:$`)
+	// flagLine matches the first line of a flag printed by pflag.
+	flagLine    = regexp.MustCompile(`^  (?:-\S, |    )--([^\s=\[]+)`)
+	nonAlphaNum = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// flagSections groups the flags by the named flag sets of the command. The
+// named flag sets are not kept by the command, so they are recovered from the
+// usage output which cliflag.SetUsageAndHelpFunc prints by section. Flags not
+// found in any section are put into an "Other" section at the end. Nil is
+// returned if the command does not use named flag sets. The output writers of
+// the command are restored after the usage is printed.
+func flagSections(cmd *cobra.Command, flags *pflag.FlagSet) []flagSection {
+	var buf bytes.Buffer
+	out, errOut := cmd.OutOrStdout(), cmd.ErrOrStderr()
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	err := cmd.Usage()
+	cmd.SetOut(out)
+	cmd.SetErr(errOut)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	sectionOf := make(map[string]string)
+	current := ""
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if m := sectionHeading.FindStringSubmatch(line); m != nil {
+			current = m[1]
+			names = append(names, current)
+			continue
+		}
+		if m := flagLine.FindStringSubmatch(line); m != nil && current != "" {
+			if _, ok := sectionOf[m[1]]; !ok {
+				sectionOf[m[1]] = current
+			}
+		}
+	}
+	if len(names) < 2 {
+		return nil
+	}
+
+	sets := make(map[string]*pflag.FlagSet)
+	flags.VisitAll(func(flag *pflag.Flag) {
+		name, ok := sectionOf[flag.Name]
+		if !ok {
+			name = "Other"
+		}
+		if sets[name] == nil {
+			sets[name] = pflag.NewFlagSet(name, pflag.ContinueOnError)
+			sets[name].SortFlags = flags.SortFlags
+		}
+		sets[name].AddFlag(flag)
+	})
+	if _, ok := sets["Other"]; ok {
+		names = append(names, "Other")
+	}
+
+	var result []flagSection
+	for _, name := range names {
+		fs, ok := sets[name]
+		if !ok || !hasVisibleFlags(fs) {
+			continue
+		}
+		result = append(result, flagSection{
+			Name:   name,
+			Anchor: "options-" + strings.Trim(nonAlphaNum.ReplaceAllString(strings.ToLower(name), "-"), "-"),
+			Flags:  fs,
+		})
+	}
+	return result
+}
+
+func hasVisibleFlags(fs *pflag.FlagSet) bool {
+	visible := false
+	fs.VisitAll(func(flag *pflag.Flag) {
+		if !flag.Hidden {
+			visible = true
+		}
+	})
+	return visible
+}
+
+// printFlagSections prints a table of contents of the sections followed by
+// the flags of each section under its own heading.
+func printFlagSections(w io.Writer, cmd *cobra.Command, sections []flagSection, withTitle bool) error {
+	for _, s := range sections {
+		if _, err := fmt.Fprintf(w, "- [%s flags](#%s)\n", s.Name, s.Anchor); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprint(w, "\n"); err != nil {
+		return err
+	}
+
+	level := "###"
+	if !withTitle {
+		level = "####"
+	}
+	for _, s := range sections {
+		if _, err := fmt.Fprintf(w, "%s %s flags {#%s}\n\n", level, s.Name, s.Anchor); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprint(w, flagUsages(cmd, s.Flags)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
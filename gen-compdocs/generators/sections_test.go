@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	cliflag "k8s.io/component-base/cli/flag"
+)
+
+// TestFlagSections checks that the sections are recovered from the usage
+// printed by cliflag.SetUsageAndHelpFunc, so that a change of its format
+// fails here instead of putting all the flags in a single table.
+func TestFlagSections(t *testing.T) {
+	var nfs cliflag.NamedFlagSets
+	generic := nfs.FlagSet("generic")
+	generic.String("bind-address", "0.0.0.0", "The IP address to listen on.")
+	generic.BoolP("verbose", "v", false, "Print more output.")
+	misc := nfs.FlagSet("misc options")
+	misc.StringSlice("feature-gates", nil, "A set of key=value pairs.")
+
+	cmd := &cobra.Command{Use: "kube-server"}
+	for _, name := range nfs.Order {
+		cmd.Flags().AddFlagSet(nfs.FlagSets[name])
+	}
+	cmd.Flags().Int("unsectioned", 0, "A flag outside of the named flag sets.")
+	cliflag.SetUsageAndHelpFunc(cmd, nfs, 80)
+
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+
+	sections := flagSections(cmd, cmd.Flags())
+
+	type section struct {
+		Name, Anchor string
+		Flags        []string
+	}
+	var got []section
+	for _, s := range sections {
+		var names []string
+		s.Flags.VisitAll(func(flag *pflag.Flag) {
+			names = append(names, flag.Name)
+		})
+		got = append(got, section{Name: s.Name, Anchor: s.Anchor, Flags: names})
+	}
+	want := []section{
+		{Name: "Generic", Anchor: "options-generic", Flags: []string{"bind-address", "verbose"}},
+		{Name: "Misc options", Anchor: "options-misc-options", Flags: []string{"feature-gates"}},
+		{Name: "Other", Anchor: "options-other", Flags: []string{"unsectioned"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected sections\ngot:  %+v\nwant: %+v", got, want)
+	}
+
+	if cmd.OutOrStdout() != &out || cmd.ErrOrStderr() != &errOut {
+		t.Errorf("the output writers of the command were not restored")
+	}
+	if out.Len() != 0 || errOut.Len() != 0 {
+		t.Errorf("the usage was written to the output of the command: %q %q", out.String(), errOut.String())
+	}
+}
+
+func TestFlagSectionsWithoutNamedFlagSets(t *testing.T) {
+	cmd := &cobra.Command{Use: "tool"}
+	cmd.Flags().String("name", "", "A name.")
+	if sections := flagSections(cmd, cmd.Flags()); sections != nil {
+		t.Errorf("expected no sections for a command without named flag sets, got %d", len(sections))
+	}
+}
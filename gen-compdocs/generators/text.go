@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generators
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// GenTextTree writes a plain-text page for the command and each of its
+// subcommands, e.g. "kubeadm_init_phase.txt".
+func GenTextTree(cmd *cobra.Command, dir string) error {
+	for _, c := range cmd.Commands() {
+		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		if err := GenTextTree(c, dir); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	genText(cmd, &buf)
+	filename := filepath.Join(dir, strings.ReplaceAll(cmd.CommandPath(), " ", "_")+".txt")
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
+func genText(cmd *cobra.Command, buf *bytes.Buffer) {
+	cmd.InitDefaultHelpCmd()
+	cmd.InitDefaultHelpFlag()
+
+	long := cmd.Long
+	if len(long) == 0 {
+		long = cmd.Short
+	}
+
+	fmt.Fprintf(buf, "%s - %s\n\n", cmd.CommandPath(), cmd.Short)
+	fmt.Fprintf(buf, "Synopsis:\n%s\n\n", indent(long, 2))
+
+	fmt.Fprintf(buf, "Usage:\n")
+	if cmd.Runnable() {
+		fmt.Fprintf(buf, "  %s\n", cmd.UseLine())
+	}
+	if cmd.HasAvailableSubCommands() {
+		fmt.Fprintf(buf, "  %s [command]\n", cmd.CommandPath())
+	}
+	fmt.Fprintf(buf, "\n")
+
+	if len(cmd.Example) > 0 {
+		fmt.Fprintf(buf, "Examples:\n%s\n\n", strings.TrimRight(cmd.Example, "\n"))
+	}
+
+	textOptions(buf, cmd, "Options", cmd.NonInheritedFlags())
+	textOptions(buf, cmd, "Options inherited from parent commands", cmd.InheritedFlags())
+
+	if hasSeeAlso(cmd) {
+		fmt.Fprintf(buf, "See also:\n")
+		if cmd.HasParent() {
+			parent := cmd.Parent()
+			fmt.Fprintf(buf, "  %s - %s\n", parent.CommandPath(), parent.Short)
+		}
+		children := cmd.Commands()
+		sort.Sort(byName(children))
+		for _, c := range children {
+			if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
+				continue
+			}
+			fmt.Fprintf(buf, "  %s - %s\n", c.CommandPath(), c.Short)
+		}
+		fmt.Fprintf(buf, "\n")
+	}
+}
+
+// textOptions lists the flags the same way as the options tables of the
+// Markdown pages, deprecated flags included.
+func textOptions(buf *bytes.Buffer, cmd *cobra.Command, title string, flags *pflag.FlagSet) {
+	if !hasVisibleFlags(flags) {
+		return
+	}
+	fmt.Fprintf(buf, "%s:\n", title)
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+		varname, usage := unquoteUsage(flag)
+		varname = html.UnescapeString(varname)
+
+		line := "      --" + flag.Name
+		if len(flag.Shorthand) > 0 && len(flag.ShorthandDeprecated) == 0 {
+			line = "  -" + flag.Shorthand + ", --" + flag.Name
+		}
+		if len(varname) > 0 {
+			line += " " + varname
+		}
+		if !defaultIsZeroValue(flag) {
+			line += fmt.Sprintf(" (default %s)", normalizeDefault(cmd, flag))
+		}
+		if len(flag.Deprecated) > 0 {
+			usage += " (DEPRECATED: " + flag.Deprecated + ")"
+		}
+		fmt.Fprintf(buf, "%s\n%s\n", line, indent(usage, 8))
+	})
+	fmt.Fprintf(buf, "\n")
+}
+
+// indent indents each non-empty line of the text by n spaces.
+func indent(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = strings.Repeat(" ", n) + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
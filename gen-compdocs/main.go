@@ -17,24 +17,11 @@ limitations under the License.
 package main
 
 import (
-	"log"
-	"os"
-
-	"github.com/kubernetes-sigs/reference-docs/gen-compdocs/comps"
+	// Registers the Kubernetes components.
+	_ "github.com/kubernetes-sigs/reference-docs/gen-compdocs/comps"
+	"github.com/kubernetes-sigs/reference-docs/gen-compdocs/gendocs"
 )
 
 func main() {
-	// use os.Args instead of "flags" because "flags" will mess up the man pages!
-	path := ""
-	module := ""
-	if len(os.Args) == 3 {
-		path = os.Args[1]
-		module = os.Args[2]
-	} else {
-		log.Fatalf("usage: %s <output-dir> <module>", os.Args[0])
-	}
-
-	if err := comps.GenerateFiles(path, module); err != nil {
-		log.Fatalf("failure: %v", err)
-	}
+	gendocs.Main()
 }
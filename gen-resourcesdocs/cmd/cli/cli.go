@@ -15,6 +15,8 @@ const (
 	outputDirOption       = "output-dir"
 	templatesDirOption    = "templates"
 	showDefinitionsOption = "show-definitions"
+	strictOption          = "strict"
+	diagnosticsFileOption = "diagnostics-file"
 )
 
 // RootCmd defines the root cli command
@@ -34,7 +34,17 @@ func KWebsite() *cobra.Command {
 			if show {
 				toc.OutputDocumentedDefinitions()
 			}
-			return nil
+
+			if diagnosticsFile := cmd.Flag(diagnosticsFileOption).Value.String(); diagnosticsFile != "" {
+				if err = toc.Diagnostics.WriteReport(diagnosticsFile); err != nil {
+					return fmt.Errorf("Unable to write diagnostics: %v", err)
+				}
+			}
+			strict, err := cmd.Flags().GetBool(strictOption)
+			if err != nil {
+				return err
+			}
+			return toc.Diagnostics.Err(strict)
 		},
 	}
 	cmd.Flags().StringP(configDirOption, "c", "", "Directory containing documentation configuration")
@@ -44,5 +54,7 @@ func KWebsite() *cobra.Command {
 	cmd.Flags().StringP(templatesDirOption, "t", "", "Directory containing go templates for output")
 	cmd.MarkFlagRequired(templatesDirOption)
 	cmd.Flags().Bool(showDefinitionsOption, false, "Show where definitions are defined on output")
+	cmd.Flags().Bool(strictOption, false, "Fail on warnings as well as errors")
+	cmd.Flags().String(diagnosticsFileOption, "", "File to write the warnings and errors as JSON, '-' for standard output")
 	return cmd
 }
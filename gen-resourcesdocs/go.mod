@@ -6,6 +6,8 @@ require (
 	github.com/go-openapi/jsonreference v0.21.4
 	github.com/go-openapi/loads v0.23.2
 	github.com/go-openapi/spec v0.22.2
+	github.com/kubernetes-sigs/reference-docs/diagnostics v0.0.0
+	github.com/kubernetes-sigs/reference-docs/manifest v0.0.0
 	github.com/spf13/cobra v1.10.0
 	github.com/spf13/viper v1.21.0
 	github.com/stoewer/go-strcase v1.3.0
@@ -54,3 +56,8 @@ require (
 	golang.org/x/sys v0.37.0 // indirect
 	golang.org/x/text v0.30.0 // indirect
 )
+
+replace (
+	github.com/kubernetes-sigs/reference-docs/diagnostics => ../diagnostics
+	github.com/kubernetes-sigs/reference-docs/manifest => ../manifest
+)
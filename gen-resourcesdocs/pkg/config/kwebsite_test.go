@@ -0,0 +1,156 @@
+package config_test
+
+import (
+	"bytes"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/kubernetes-sigs/reference-docs/gen-resourcesdocs/pkg/config"
+	"github.com/kubernetes-sigs/reference-docs/gen-resourcesdocs/pkg/kubernetes"
+)
+
+// update rewrites the golden trees under testdata/golden from the output
+// of the tests: go test ./pkg/config -run Golden -update
+var update = flag.Bool("update", false, "rewrite the golden trees from test output")
+
+// TestKWebsiteGolden outputs the fixture under testdata/kwebsite, a small
+// subset of the Kubernetes API, for k/website and compares all the written
+// files with the ones under testdata/golden/kwebsite.
+func TestKWebsiteGolden(t *testing.T) {
+	outputDir, toc := outputKWebsiteFixture(t)
+
+	compareTreeWithGolden(t, outputDir, "testdata/golden/kwebsite")
+
+	if report := toc.Diagnostics.Report(); !report.Clean {
+		t.Errorf("Output of the fixture should be clean, got diagnostics %v", report.Diagnostics)
+	}
+}
+
+// TestKWebsiteDeterministic outputs the fixture twice and checks that both
+// runs write the same files, so that rerunning a release gives no spurious
+// diff in k/website.
+func TestKWebsiteDeterministic(t *testing.T) {
+	first, _ := outputKWebsiteFixture(t)
+	second, _ := outputKWebsiteFixture(t)
+	compareTrees(t, readTree(t, second), readTree(t, first), "first run")
+}
+
+// outputKWebsiteFixture outputs the fixture under testdata/kwebsite for
+// k/website into a temporary directory, which it returns with the TOC.
+func outputKWebsiteFixture(t *testing.T) (string, *config.TOC) {
+	t.Helper()
+	spec, err := kubernetes.NewSpec("testdata/kwebsite/swagger.json")
+	if err != nil {
+		t.Fatalf("Error loading swagger file: %v", err)
+	}
+
+	// Same steps as the kwebsite command
+	toc, err := config.LoadTOC("testdata/kwebsite/toc.yaml")
+	if err != nil {
+		t.Fatalf("LoadTOC should not fail: %v", err)
+	}
+	if err = toc.PopulateAssociates(spec); err != nil {
+		t.Fatalf("PopulateAssociates should not fail: %v", err)
+	}
+	toc.AddOtherResources(spec)
+	toc.Definitions = &spec.Swagger.Definitions
+	toc.Actions = spec.Actions
+	toc.Actions.Sort()
+	toc.Categories, err = config.LoadCategories([]string{"testdata/kwebsite/fields.yaml"})
+	if err != nil {
+		t.Fatalf("LoadCategories should not fail: %v", err)
+	}
+
+	outputDir := t.TempDir()
+	if err = toc.ToKWebsite(outputDir, "../../templates"); err != nil {
+		t.Fatalf("ToKWebsite should not fail: %v", err)
+	}
+	return outputDir, toc
+}
+
+// compareTreeWithGolden compares the files under gotDir with the ones under
+// goldenDir. With -update, the golden tree is replaced with gotDir instead.
+func compareTreeWithGolden(t *testing.T, gotDir, goldenDir string) {
+	t.Helper()
+	if *update {
+		if err := os.RemoveAll(goldenDir); err != nil {
+			t.Fatalf("Unable to remove golden tree: %v", err)
+		}
+		if err := os.CopyFS(goldenDir, os.DirFS(gotDir)); err != nil {
+			t.Fatalf("Unable to write golden tree: %v", err)
+		}
+		return
+	}
+
+	want := readTree(t, goldenDir)
+	if len(want) == 0 {
+		t.Fatalf("Golden tree %s is empty, run the test with -update to create it", goldenDir)
+	}
+	compareTrees(t, readTree(t, gotDir), want, "golden file")
+}
+
+// compareTrees reports the files of got missing from want or differing from
+// them, and the files of want missing from got. wantName names want in the
+// messages.
+func compareTrees(t *testing.T, got, want map[string][]byte, wantName string) {
+	t.Helper()
+	names := []string{}
+	for name := range got {
+		names = append(names, name)
+	}
+	for name := range want {
+		if _, found := got[name]; !found {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		g, inGot := got[name]
+		w, inWant := want[name]
+		switch {
+		case !inWant:
+			t.Errorf("File %s should not be written", name)
+		case !inGot:
+			t.Errorf("File %s should be written", name)
+		case !bytes.Equal(g, w):
+			t.Errorf("File %s differs from %s\n--- got ---\n%s\n--- want ---\n%s", name, wantName, g, w)
+		}
+	}
+}
+
+// readTree returns the content of the files under dir, by slash-separated
+// path relative to dir
+func readTree(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+	files := map[string][]byte{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unable to read tree %s: %v", dir, err)
+	}
+	return files
+}
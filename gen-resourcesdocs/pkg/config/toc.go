@@ -7,8 +7,10 @@ import (
 	"sort"
 
 	"github.com/go-openapi/spec"
+	"github.com/kubernetes-sigs/reference-docs/diagnostics"
 	"github.com/kubernetes-sigs/reference-docs/gen-resourcesdocs/pkg/kubernetes"
 	"github.com/kubernetes-sigs/reference-docs/gen-resourcesdocs/pkg/outputs/kwebsite"
+	"github.com/kubernetes-sigs/reference-docs/manifest"
 	"gopkg.in/yaml.v2"
 )
 
@@ -21,6 +23,9 @@ type TOC struct {
 	DocumentedDefinitions map[kubernetes.Key][]string
 	Actions               kubernetes.Actions
 	Categories            Categories
+	// Diagnostics collects the warnings and errors found while outputting
+	// the documentation
+	Diagnostics *diagnostics.Collector
 }
 
 // Part contains chapters
@@ -76,6 +81,7 @@ func LoadTOC(filename string) (*TOC, error) {
 	}
 
 	result.DocumentedDefinitions = map[kubernetes.Key][]string{}
+	result.Diagnostics = diagnostics.New(os.Stderr)
 	return &result, nil
 }
 
@@ -112,7 +118,10 @@ func (o *TOC) AddOtherResources(spec *kubernetes.Spec) {
 		}
 	}
 	sort.Slice(part.Chapters, func(i, j int) bool {
-		return part.Chapters[i].Name < part.Chapters[j].Name
+		if part.Chapters[i].Name != part.Chapters[j].Name {
+			return part.Chapters[i].Name < part.Chapters[j].Name
+		}
+		return part.Chapters[i].Key < part.Chapters[j].Key
 	})
 	if len(part.Chapters) > 0 {
 		o.Parts = append(o.Parts, part)
@@ -140,32 +149,51 @@ func GetGV(group kubernetes.APIGroup, version kubernetes.APIVersion) string {
 	return fmt.Sprintf("%s/%s", group, version.String())
 }
 
-// ToKWebsite outputs documentation in Markdown format for k/website in dir directory
+// ToKWebsite outputs documentation in Markdown format for k/website in dir directory.
+// Only the files whose content changed are written, and the files of the previous
+// run which are not generated anymore are removed
 func (o *TOC) ToKWebsite(outputDir string, templatesDir string) error {
-	kw := kwebsite.NewKWebsite(outputDir, templatesDir)
-	return o.OutputDocument(kw)
+	stats, err := manifest.Generate(outputDir, "gen-resourcesdocs", func(staging string) error {
+		kw := kwebsite.NewKWebsite(staging, templatesDir, o.Diagnostics)
+		return o.OutputDocument(kw)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Updated %s: %s\n", outputDir, stats)
+	return nil
 }
 
 // OutputDocumentedDefinitions outputs the list of definitions
 // and on which properties they are defined
 func (o *TOC) OutputDocumentedDefinitions() {
-	for k, v := range o.DocumentedDefinitions {
-		if len(v) != 1 {
+	documented := make([]string, 0, len(o.DocumentedDefinitions))
+	for k := range o.DocumentedDefinitions {
+		documented = append(documented, k.String())
+	}
+	sort.Strings(documented)
+	for _, k := range documented {
+		if v := o.DocumentedDefinitions[kubernetes.Key(k)]; len(v) != 1 {
 			fmt.Printf("%s:\n", k)
 			for _, s := range v {
 				fmt.Printf(" - %s\n", s)
 			}
 		}
 	}
-	for k, v := range o.DocumentedDefinitions {
-		if len(v) == 1 {
+	for _, k := range documented {
+		if v := o.DocumentedDefinitions[kubernetes.Key(k)]; len(v) == 1 {
 			fmt.Printf("%s:\n", k)
 			for _, s := range v {
 				fmt.Printf(" - %s\n", s)
 			}
 		}
 	}
+	definitions := make([]string, 0, len(*o.Definitions))
 	for k := range *o.Definitions {
+		definitions = append(definitions, k)
+	}
+	sort.Strings(definitions)
+	for _, k := range definitions {
 		if _, found := o.DocumentedDefinitions[kubernetes.Key(k)]; !found {
 			fmt.Printf("%s\n", k)
 		}
@@ -137,7 +137,10 @@ func (a ActionInfoList) Less(i, j int) bool {
 	if a[j].Action.LessThan(a[i].Action) {
 		return false
 	}
-	return a[i].Path.LessThan(a[j].Path)
+	if a[i].Path != a[j].Path {
+		return a[i].Path.LessThan(a[j].Path)
+	}
+	return a[i].HTTPMethod < a[j].HTTPMethod
 }
 
 // Actions represents a map of ActionInfo, mapped by GVK
@@ -204,8 +207,16 @@ func (o Actions) Sort() {
 }
 
 func (o Actions) findCommonParameters() {
-	for _, actionList := range o {
-		for _, action := range actionList {
+	// The common parameters are the ones of the last loaded spec only
+	ResourcesDescriptions = ResourcesMap{}
+	ParametersAnnex = map[string]struct{}{}
+	gvks := make([]string, 0, len(o))
+	for gvk := range o {
+		gvks = append(gvks, gvk)
+	}
+	sort.Strings(gvks)
+	for _, gvk := range gvks {
+		for _, action := range o[gvk] {
 			ResourcesDescriptions.addActionParameters(&action.Parameters)
 		}
 	}
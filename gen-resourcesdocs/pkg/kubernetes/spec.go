@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/go-openapi/spec"
@@ -82,7 +83,15 @@ func (o *Spec) getResources() error {
 	o.Resources = &ResourceMap{}
 	o.GVToKey = GVToKeyMap{}
 
-	for key, definition := range o.Swagger.Definitions {
+	// Visit the definitions in order, so that the resources and keys are listed
+	// in the same order on every run
+	keys := make([]string, 0, len(o.Swagger.Definitions))
+	for key := range o.Swagger.Definitions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		definition := o.Swagger.Definitions[key]
 		gvk, found, err := getGVKExtension(definition.Extensions)
 		if err != nil {
 			return fmt.Errorf("%s: %f", key, err)
@@ -128,7 +137,13 @@ func (o *Spec) getActions() error {
 	o.Actions = make(Actions)
 
 	paths := o.Swagger.Paths.Paths
-	for key, path := range paths {
+	keys := make([]string, 0, len(paths))
+	for key := range paths {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		path := paths[key]
 		if path.Get != nil {
 			o.Actions.Add(o.Swagger.Parameters, key, path.Get, "GET", path.Parameters)
 		}
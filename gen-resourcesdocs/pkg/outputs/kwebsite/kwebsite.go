@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/kubernetes-sigs/reference-docs/diagnostics"
 	"github.com/kubernetes-sigs/reference-docs/gen-resourcesdocs/pkg/outputs"
 )
 
@@ -13,11 +14,13 @@ import (
 type KWebsite struct {
 	Directory    string
 	TemplatesDir string
+	// Diagnostics collects the problems found while writing the output
+	Diagnostics *diagnostics.Collector
 }
 
 // NewKWebsite returns a new KWebsite
-func NewKWebsite(dir string, templatesDir string) *KWebsite {
-	return &KWebsite{Directory: dir, TemplatesDir: templatesDir}
+func NewKWebsite(dir string, templatesDir string, diags *diagnostics.Collector) *KWebsite {
+	return &KWebsite{Directory: dir, TemplatesDir: templatesDir, Diagnostics: diags}
 }
 
 // NewPart creates a new part for the output
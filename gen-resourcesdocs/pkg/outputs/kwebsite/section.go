@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/kubernetes-sigs/reference-docs/diagnostics"
 	"github.com/kubernetes-sigs/reference-docs/gen-resourcesdocs/pkg/kubernetes"
 	// "github.com/yuin/goldmark"
 	// highlighting "github.com/yuin/goldmark-highlighting"
@@ -162,7 +163,7 @@ func (o Section) AddProperty(name string, property *kubernetes.Property, linkend
 		Indent:      indent,
 	})
 	if indent > 3 {
-		fmt.Printf("Warning: %s/%s indentation is %d\n", o.chapter.name, name, indent)
+		o.kwebsite.Diagnostics.Warningf("deep-indentation", diagnostics.Location{Definition: defname, Field: name}, "indentation of the field is %d in %s", indent, o.chapter.name)
 	}
 	return nil
 }
@@ -181,7 +182,7 @@ func (o Section) EndPropertyList() error {
 func (o Section) AddOperation(operation *kubernetes.ActionInfo, linkends kubernetes.LinkEnds) error {
 	sentences := strings.Split(operation.Operation.Description, ".")
 	if len(sentences) > 1 {
-		fmt.Printf("SHOULD NOT HAPPEN, sentences: %d\n", len(sentences))
+		o.kwebsite.Diagnostics.Warningf("multi-sentence-operation", diagnostics.Location{Operation: operation.Operation.ID}, "description of the operation has %d sentences, expected 1", len(sentences))
 	}
 
 	dataParams := []ParameterData{}
@@ -194,12 +195,11 @@ func (o Section) AddOperation(operation *kubernetes.ActionInfo, linkends kuberne
 		typ := param.Type
 		if param.Schema != nil {
 			t, typeKey := kubernetes.GetTypeNameAndKey(*param.Schema)
-			linkend, found := linkends[*typeKey]
-			if found {
+			if linkend, found := linkends[*typeKey]; found {
 				typ = o.kwebsite.LinkEnd(linkend, t)
 			} else {
 				typ = t
-				fmt.Printf("SHOULD NOT HAPPEN: %s\n", typeKey)
+				o.kwebsite.Diagnostics.Errorf("missing-link-end", diagnostics.Location{Definition: typeKey.String(), Operation: operation.Operation.ID}, "no link end for the type of parameter %s", param.Name)
 			}
 		}
 
@@ -229,12 +229,11 @@ func (o Section) AddOperation(operation *kubernetes.ActionInfo, linkends kuberne
 		if response.Schema != nil {
 			t, typeKey := kubernetes.GetTypeNameAndKey(*response.Schema)
 			if typeKey != nil {
-				linkend, found := linkends[*typeKey]
-				if found {
+				if linkend, found := linkends[*typeKey]; found {
 					typ = o.kwebsite.LinkEnd(linkend, t)
 				} else {
 					typ = t
-					fmt.Printf("SHOULD NOT HAPPEN: %s\n", typeKey)
+					o.kwebsite.Diagnostics.Errorf("missing-link-end", diagnostics.Location{Definition: typeKey.String(), Operation: operation.Operation.ID}, "no link end for the type of response %d", code)
 				}
 			} else {
 				typ = t
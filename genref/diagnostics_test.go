@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kubernetes-sigs/reference-docs/diagnostics"
+)
+
+// TestDiagnostics runs genref with invalid configs and checks that the run
+// fails with the errors reported in the diagnostics file.
+func TestDiagnostics(t *testing.T) {
+	bin := buildGenref(t)
+
+	cases := []struct {
+		name   string
+		config string
+		code   string
+		// message is a part of the message of the diagnostics, if set.
+		message string
+	}{
+		{
+			name: "external package pattern",
+			config: `externalPackages:
+  - match: "^k8s.io/apimachinery/pkg/apis/meta/v1.(Object"
+    target: https://example.com/{{ .TypeIdentifier }}
+apis:
+  - name: fixture
+    title: Fixture (v1)
+    package: github.com/kubernetes-sigs/reference-docs/genref
+    path: testdata/fixture/apis/v1
+`,
+			code: "invalid-external-package",
+		},
+		{
+			name: "empty path",
+			config: `apis:
+  - name: fixture
+    title: Fixture (v1)
+    package: github.com/kubernetes-sigs/reference-docs/genref
+    subPackages: true
+`,
+			code:    "invalid-api-path",
+			message: "path of the API packages is required",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dir := t.TempDir()
+			configFile := filepath.Join(dir, "config.yaml")
+			if err := os.WriteFile(configFile, []byte(c.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			diagnosticsFile := filepath.Join(dir, "diagnostics.json")
+
+			cmd := exec.Command(bin, "-c", configFile, "-o", t.TempDir(), "-kubernetes-release", "1.0", "-diagnostics-file", diagnosticsFile)
+			out, err := cmd.CombinedOutput()
+			var exitErr *exec.ExitError
+			if !errors.As(err, &exitErr) {
+				t.Fatalf("genref should fail on errors, got %v\n%s", err, out)
+			}
+
+			data, err := os.ReadFile(diagnosticsFile)
+			if err != nil {
+				t.Fatalf("failed to read diagnostics: %v", err)
+			}
+			var report diagnostics.Report
+			if err := json.Unmarshal(data, &report); err != nil {
+				t.Fatalf("failed to parse diagnostics: %v", err)
+			}
+			if report.Clean || report.Errors == 0 {
+				t.Fatalf("got clean=%v errors=%d, want errors", report.Clean, report.Errors)
+			}
+			for _, d := range report.Diagnostics {
+				if d.Code != c.code || d.Location.Definition == "" || !strings.Contains(d.Message, c.message) {
+					t.Errorf("unexpected diagnostic %v", d)
+				}
+			}
+		})
+	}
+}
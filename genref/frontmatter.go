@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+)
+
+// frontMatter is the Hugo front matter written at the top of a Markdown page.
+// The fields are emitted in the same order as the gen-compdocs tool does for
+// the command line tool references.
+type frontMatter struct {
+	// Title of the page, defaults to the title of the API definition.
+	Title string `json:"title,omitempty"`
+
+	// ContentType is the page layout, defaults to "tool-reference".
+	ContentType string `json:"contentType,omitempty"`
+
+	// Package is the API group and version documented, which is detected
+	// from the API packages if not set.
+	Package string `json:"package,omitempty"`
+
+	// Weight orders the page in the section. It is omitted when zero.
+	Weight int `json:"weight,omitempty"`
+
+	// AutoGenerated marks the page as generated, defaults to true.
+	AutoGenerated *bool `json:"autoGenerated,omitempty"`
+
+	// Description is a short summary of the page.
+	Description string `json:"description,omitempty"`
+
+	// Aliases are the old URLs that redirect to the page.
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// IsAutoGenerated returns the value for the auto_generated field.
+func (fm *frontMatter) IsAutoGenerated() bool {
+	return fm.AutoGenerated == nil || *fm.AutoGenerated
+}
+
+// pageFrontMatter returns the front matter for the page of an API definition,
+// filling in the defaults for the fields not customized in the config.
+func pageFrontMatter(def *apiDefinition, pkgs []*apiPackage) *frontMatter {
+	fm := frontMatter{}
+	if def.FrontMatter != nil {
+		fm = *def.FrontMatter
+	}
+	if fm.Title == "" {
+		fm.Title = def.Title
+	}
+	if fm.ContentType == "" {
+		fm.ContentType = "tool-reference"
+	}
+	if fm.Package == "" {
+		if p := mainPackage(pkgs); p != nil {
+			fm.Package = p.DisplayName()
+		} else if len(pkgs) > 0 {
+			// packages without a group name, e.g. kubeconfig
+			fm.Package = pkgs[0].apiVersion
+		}
+	}
+	// The description is emitted as a folded block scalar on a single line.
+	fm.Description = strings.Join(strings.Fields(fm.Description), " ")
+	return &fm
+}
+
+// kindFrontMatter returns the front matter for the page of a top-level kind
+// when the output is split. Only the layout related fields are inherited from
+// the page of the API definition.
+func kindFrontMatter(parent *frontMatter, kind *apiType, weight int) *frontMatter {
+	return &frontMatter{
+		Title:         kind.Name.Name,
+		ContentType:   parent.ContentType,
+		Package:       parent.Package,
+		Weight:        weight,
+		AutoGenerated: parent.AutoGenerated,
+	}
+}
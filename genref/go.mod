@@ -3,6 +3,8 @@ module github.com/kubernetes-sigs/reference-docs/genref
 go 1.26.0
 
 require (
+	github.com/kubernetes-sigs/reference-docs/diagnostics v0.0.0
+	github.com/kubernetes-sigs/reference-docs/manifest v0.0.0
 	github.com/tengqm/kubeconfig v0.0.0-20251221114914-bf1e235fad69
 	github.com/yuin/goldmark v1.7.8
 	github.com/yuin/goldmark-highlighting v0.0.0-20210516132338-9216f9c5aa01
@@ -48,6 +50,7 @@ require (
 	github.com/x448/float16 v0.8.4 // indirect
 	go.yaml.in/yaml/v2 v2.4.3 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
+	golang.org/x/crypto v0.47.0 // indirect
 	golang.org/x/net v0.49.0 // indirect
 	golang.org/x/oauth2 v0.34.0 // indirect
 	golang.org/x/sys v0.40.0 // indirect
@@ -64,3 +67,8 @@ require (
 	sigs.k8s.io/randfill v1.0.0 // indirect
 	sigs.k8s.io/structured-merge-diff/v6 v6.3.2 // indirect
 )
+
+replace (
+	github.com/kubernetes-sigs/reference-docs/diagnostics => ../diagnostics
+	github.com/kubernetes-sigs/reference-docs/manifest => ../manifest
+)
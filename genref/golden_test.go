@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// update rewrites the golden trees under testdata/golden from the output of
+// the test: go test -run TestGolden -update
+var update = flag.Bool("update", false, "rewrite the golden trees from test output")
+
+// TestGolden runs genref on the fixture API under testdata/fixture, on the
+// one under testdata/overrides whose group and version are set by the config
+// and on the module under testdata/module given with -module-dir, and
+// compares the output with the golden trees under testdata/golden. It runs with -strict, so the fixtures must not cause any
+// warning, and checks the links between the pages of the split output with
+// verify-links.
+//
+// genref exits on failures and works with global state, so the test builds
+// the binary and runs it instead of calling main.
+func TestGolden(t *testing.T) {
+	bin := buildGenref(t)
+	verifyLinks := buildVerifyLinks(t)
+
+	cases := []struct {
+		name       string
+		config     string
+		args       []string
+		checkLinks bool
+	}{
+		{name: "markdown", args: []string{"-f", "markdown"}},
+		{name: "html", args: []string{"-f", "html"}},
+		{name: "markdown-split", args: []string{"-f", "markdown", "-split"}, checkLinks: true},
+		{name: "html-split", args: []string{"-f", "html", "-split"}, checkLinks: true},
+		{name: "overrides", config: "testdata/overrides/config.yaml", args: []string{"-f", "markdown"}},
+		{name: "module", config: "testdata/module/config.yaml", args: []string{"-f", "markdown", "-module-dir", "testdata/module"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			outDir := t.TempDir()
+			config := c.config
+			if config == "" {
+				config = "testdata/fixture/config.yaml"
+			}
+			args := append([]string{"-c", config, "-o", outDir, "-kubernetes-release", "1.0", "-strict"}, c.args...)
+			cmd := exec.Command(bin, args...)
+			// Keep the git commit out of the HTML output.
+			cmd.Env = append(os.Environ(), "GIT_DIR="+t.TempDir())
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("genref failed: %v\n%s", err, out)
+			}
+			if c.checkLinks {
+				if out, err := exec.Command(verifyLinks, outDir).CombinedOutput(); err != nil {
+					t.Errorf("verify-links failed: %v\n%s", err, out)
+				}
+			}
+			compareTreeWithGolden(t, outDir, filepath.Join("testdata", "golden", c.name))
+		})
+	}
+}
+
+// buildGenref builds the genref binary into a temporary directory and
+// returns its path.
+func buildGenref(t *testing.T) string {
+	t.Helper()
+	bin := filepath.Join(t.TempDir(), "genref")
+	if out, err := exec.Command("go", "build", "-o", bin, ".").CombinedOutput(); err != nil {
+		t.Fatalf("failed to build genref: %v\n%s", err, out)
+	}
+	return bin
+}
+
+// buildVerifyLinks builds the verify-links tool of gen-apidocs into a
+// temporary directory and returns its path.
+func buildVerifyLinks(t *testing.T) string {
+	t.Helper()
+	bin := filepath.Join(t.TempDir(), "verify-links")
+	cmd := exec.Command("go", "build", "-o", bin, "./verify-links")
+	cmd.Dir = filepath.Join("..", "gen-apidocs")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("failed to build verify-links: %v\n%s", err, out)
+	}
+	return bin
+}
+
+// compareTreeWithGolden compares the files under gotDir with the ones under
+// goldenDir. With -update, the golden tree is replaced with gotDir instead.
+func compareTreeWithGolden(t *testing.T, gotDir, goldenDir string) {
+	t.Helper()
+	if *update {
+		if err := os.RemoveAll(goldenDir); err != nil {
+			t.Fatalf("failed to remove golden tree: %v", err)
+		}
+		if err := os.CopyFS(goldenDir, os.DirFS(gotDir)); err != nil {
+			t.Fatalf("failed to write golden tree: %v", err)
+		}
+		return
+	}
+
+	got := readTree(t, gotDir)
+	want := readTree(t, goldenDir)
+	if len(want) == 0 {
+		t.Fatalf("golden tree %s is empty, run the test with -update to create it", goldenDir)
+	}
+
+	var names []string
+	for name := range got {
+		names = append(names, name)
+	}
+	for name := range want {
+		if _, ok := got[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		g, inGot := got[name]
+		w, inWant := want[name]
+		switch {
+		case !inWant:
+			t.Errorf("unexpected file %s", name)
+		case !inGot:
+			t.Errorf("missing file %s", name)
+		case !bytes.Equal(g, w):
+			t.Errorf("%s differs from the golden file\n--- got ---\n%s\n--- want ---\n%s", name, g, w)
+		}
+	}
+}
+
+// readTree returns the content of the files under dir, keyed by their
+// slash-separated path relative to dir.
+func readTree(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", dir, err)
+	}
+	return files
+}
@@ -1,19 +1,6 @@
 {{ define "packages" }}
   <html lang="en">
-    <head>
-      <meta charset="utf-8">
-      <link rel="stylesheet" href="https://cdnjs.cloudflare.com/ajax/libs/font-awesome/5.8.2/css/font-awesome.min.css">
-      <link rel="stylesheet" href="https://maxcdn.bootstrapcdn.com/bootstrap/4.3.1/css/bootstrap.min.css">
-      <style type="text/css">
-        td p {
-          margin-bottom: 0
-        }
-        code {
-          color: #802060;
-          display: inline-block;
-        }
-      </style>
-    </head>
+    {{ template "head" }}
     <body>
       <div class="container">
         {{ range .packages }}
@@ -55,10 +42,30 @@
         {{ end }}
       </div>
 
-      <div class="container">
-        <p><em>Generated with <code>genref</code>{{ with .gitCommit }} on git commit <code>{{ . }}</code>{{end}}</em></p>
-      </div>
+      {{ template "footer" . }}
     </body>
   </html>
 {{ end }}
 
+{{ define "head" }}
+    <head>
+      <meta charset="utf-8">
+      <link rel="stylesheet" href="https://cdnjs.cloudflare.com/ajax/libs/font-awesome/5.8.2/css/font-awesome.min.css">
+      <link rel="stylesheet" href="https://maxcdn.bootstrapcdn.com/bootstrap/4.3.1/css/bootstrap.min.css">
+      <style type="text/css">
+        td p {
+          margin-bottom: 0
+        }
+        code {
+          color: #802060;
+          display: inline-block;
+        }
+      </style>
+    </head>
+{{ end }}
+
+{{ define "footer" }}
+  <div class="container">
+    <p><em>Generated with <code>genref</code>{{ with .gitCommit }} on git commit <code>{{ . }}</code>{{end}}</em></p>
+  </div>
+{{ end }}
@@ -0,0 +1,42 @@
+{{ define "index" }}
+  <html lang="en">
+    {{ template "head" }}
+    <body>
+      <div class="container">
+        {{ with .main }}
+          <H2 id="{{- .Anchor -}}">Package: <span style="font-family: monospace">{{- .DisplayName -}}</span></H2>
+          <p>{{ .GetComment }}</p>
+        {{ end }}
+        <H3>Resource Types:</H3>
+        <ul>
+          {{- range .kinds -}}
+            <li>
+              <a href="{{ .Link }}">{{ .DisplayName }}</a>
+            </li>
+          {{- end -}}
+        </ul>
+        {{ range .types }}
+          {{ template "type" . }}
+        {{ end }}
+      </div>
+      {{ template "footer" . }}
+    </body>
+  </html>
+{{ end }}
+
+{{ define "kind" }}
+  <html lang="en">
+    {{ template "head" }}
+    <body>
+      <div class="container">
+        {{ with .main }}
+          <p><a href="index.html">Package: <span style="font-family: monospace">{{- .DisplayName -}}</span></a></p>
+        {{ end }}
+        {{ range .types }}
+          {{ template "type" . }}
+        {{ end }}
+      </div>
+      {{ template "footer" . }}
+    </body>
+  </html>
+{{ end }}
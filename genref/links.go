@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	texttemplate "text/template"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// pkgGoDevURL is the fallback documentation site for external types that
+// are not part of the Kubernetes API reference.
+const pkgGoDevURL = "https://pkg.go.dev/"
+
+// apiReference specifies where the Kubernetes API reference for a release
+// lives. Both fields are text templates where `{{ .Major }}` and
+// `{{ .Minor }}` expand to the numbers of the Kubernetes release.
+type apiReference struct {
+	// Spec is the path to the OpenAPI spec (swagger.json) the API reference
+	// was generated from, for example the one under gen-apidocs/config.
+	Spec string `json:"spec"`
+
+	// URL is the location of the published API reference.
+	URL string `json:"url"`
+}
+
+// kubeRelease is a Kubernetes minor release, e.g. 1.36.
+type kubeRelease struct {
+	Major string
+	Minor string
+}
+
+// String returns the release in the "1.36" form.
+func (r kubeRelease) String() string {
+	return r.Major + "." + r.Minor
+}
+
+var releaseRegex = regexp.MustCompile(`^v?(\d+)\.(\d+)`)
+
+// parseRelease parses a release string like "1.36", "v1.36" or "1.36.2".
+func parseRelease(s string) (kubeRelease, error) {
+	m := releaseRegex.FindStringSubmatch(s)
+	if m == nil {
+		return kubeRelease{}, fmt.Errorf("cannot parse Kubernetes release %q", s)
+	}
+	return kubeRelease{Major: m[1], Minor: m[2]}, nil
+}
+
+// detectRelease derives the Kubernetes release from the version of the
+// k8s.io/api module genref is built with, e.g. v0.36.0 => 1.36.
+func detectRelease() (kubeRelease, error) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return kubeRelease{}, fmt.Errorf("build info not available")
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != "k8s.io/api" {
+			continue
+		}
+		if dep.Replace != nil && dep.Replace.Version != "" {
+			dep = dep.Replace
+		}
+		r, err := parseRelease(dep.Version)
+		if err != nil {
+			return kubeRelease{}, err
+		}
+		// k8s.io/api v0.X.Y is released together with Kubernetes v1.X.Y
+		return kubeRelease{Major: "1", Minor: r.Minor}, nil
+	}
+	return kubeRelease{}, fmt.Errorf("k8s.io/api is not a dependency")
+}
+
+// expand executes a template string from the apiReference config.
+func (r kubeRelease) expand(s string) (string, error) {
+	tpl, err := texttemplate.New("").Parse(s)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	if err := tpl.Execute(&b, r); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// apiLinkIndex maps the identifier of a Kubernetes API type, in the form of
+// PackagePath.Name (e.g. k8s.io/api/core/v1.Pod), to its link in the
+// Kubernetes API reference.
+type apiLinkIndex map[string]string
+
+// swaggerSpec is the subset of an OpenAPI spec needed to build the index.
+type swaggerSpec struct {
+	Definitions map[string]struct {
+		GVK []struct {
+			Group string `json:"group"`
+		} `json:"x-kubernetes-group-version-kind"`
+	} `json:"definitions"`
+}
+
+// loadLinkIndex builds the link index for the given release.
+func loadLinkIndex(ref *apiReference, release kubeRelease) (apiLinkIndex, error) {
+	specPath, err := release.expand(ref.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("bad API reference spec %q: %w", ref.Spec, err)
+	}
+	url, err := release.expand(ref.URL)
+	if err != nil {
+		return nil, fmt.Errorf("bad API reference URL %q: %w", ref.URL, err)
+	}
+
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI spec: %w", err)
+	}
+	var spec swaggerSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec %s: %w", specPath, err)
+	}
+
+	// Work out the full group names the same way gen-apidocs does: names from
+	// the versioned gen-apidocs config win over the ones found in the
+	// x-kubernetes-group-version-kind extension, which in turn win over the
+	// short group name.
+	fullGroups := make(map[string]string)
+	for key, def := range spec.Definitions {
+		group, _, _ := guessGVK(key)
+		if group == "" || len(def.GVK) == 0 {
+			continue
+		}
+		g := def.GVK[0].Group
+		if g == "" {
+			g = "core"
+		}
+		fullGroups[group] = g
+	}
+	overrides, err := loadGroupFullNames(filepath.Join(filepath.Dir(specPath), "config.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	for short, full := range overrides {
+		fullGroups[short] = full
+	}
+
+	index := make(apiLinkIndex)
+	for key := range spec.Definitions {
+		group, version, kind := guessGVK(key)
+		if group == "" {
+			continue
+		}
+		if full, ok := fullGroups[group]; ok {
+			group = full
+		}
+		// This is the same anchor gen-apidocs generates for the definition.
+		anchor := strings.Join([]string{kind, version, group}, "-")
+		anchor = strings.ToLower(strings.ReplaceAll(anchor, ".", "-"))
+		index[swaggerImportPath(key)+"."+kind] = url + "#" + anchor
+	}
+
+	klog.Infof("Loaded %d API reference links for release %s from %s", len(index), release, specPath)
+	return index, nil
+}
+
+// loadGroupFullNames reads the group_full_names map from a gen-apidocs config
+// file. A missing file is not an error.
+func loadGroupFullNames(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var cfg struct {
+		GroupFullNames map[string]string `json:"group_full_names"`
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return cfg.GroupFullNames, nil
+}
+
+// swaggerImportPath converts a definition key such as io.k8s.api.core.v1.Pod
+// into the Go import path of its package, i.e. k8s.io/api/core/v1.
+func swaggerImportPath(key string) string {
+	parts := strings.Split(key, ".")
+	if len(parts) < 4 {
+		return ""
+	}
+	return parts[1] + "." + parts[0] + "/" + strings.Join(parts[2:len(parts)-1], "/")
+}
+
+// guessGVK mirrors the way gen-apidocs derives group, version and kind from
+// a definition key. An empty group is returned for keys that do not get an
+// entry in the API reference.
+func guessGVK(key string) (group, version, kind string) {
+	parts := strings.Split(key, ".")
+	n := len(parts)
+	if n < 4 {
+		return "", "", ""
+	}
+	switch {
+	case parts[n-3] == "api":
+		// e.g. io.k8s.apimachinery.pkg.api.resource.Quantity
+		return "core", parts[n-2], parts[n-1]
+	case parts[n-4] == "api", parts[n-4] == "apis":
+		// e.g. io.k8s.api.core.v1.Pod, io.k8s.apimachinery.pkg.apis.meta.v1.Status
+		return parts[n-3], parts[n-2], parts[n-1]
+	}
+	return "", "", ""
+}
+
+// lookup returns the API reference link for a type identifier.
+func (idx apiLinkIndex) lookup(id string) (string, bool) {
+	link, ok := idx[id]
+	return link, ok
+}
+
+// pkgGoDevLink returns the pkg.go.dev link for a type in a Go package.
+func pkgGoDevLink(pkg, name string) string {
+	return pkgGoDevURL + pkg + "#" + name
+}
+
+// loadAPIReference determines the Kubernetes release and loads the global
+// link index from the API reference configured.
+func loadAPIReference() error {
+	var release kubeRelease
+	var err error
+	if *flRelease != "" {
+		release, err = parseRelease(*flRelease)
+	} else {
+		release, err = detectRelease()
+	}
+	if err != nil {
+		return err
+	}
+	linkIndex, err = loadLinkIndex(config.APIReference, release)
+	return err
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"flag"
 	"fmt"
 	"html/template"
@@ -14,7 +15,8 @@ import (
 	"strings"
 	texttemplate "text/template"
 
-	"k8s.io/gengo/parser"
+	"github.com/kubernetes-sigs/reference-docs/diagnostics"
+	"github.com/kubernetes-sigs/reference-docs/manifest"
 	"k8s.io/gengo/types"
 	"k8s.io/klog/v2"
 
@@ -27,6 +29,11 @@ var (
 	flInclude = flag.String("include", "", "API definitions to include, comma-separated list")
 	flExclude = flag.String("exclude", "", "API definitions to exclude, comma-separated list")
 	flPath    = flag.String("o", ".", "path for the output files")
+	flSplit   = flag.Bool("split", false, "write one page per top-level kind plus an index page for each API")
+	flModule  = flag.String("module-dir", "", "path to a Go module whose API packages are documented instead of the ones genref depends on")
+	flRelease = flag.String("kubernetes-release", "", "Kubernetes release the API reference links point to, e.g. 1.36. Detected from the k8s.io/api dependency if not set.")
+	flStrict  = flag.Bool("strict", false, "fail on warnings as well as errors")
+	flDiags   = flag.String("diagnostics-file", "", "file to write the warnings and errors to as JSON, '-' for the standard output")
 	// flVerbose = flag.Bool("verbose", false, "turn on verbose output")
 )
 
@@ -57,6 +64,10 @@ type generatorConfig struct {
 	// MarkdownDisabled controls markdown rendering for comment lines.
 	MarkdownDisabled bool `json:"markdownDisabled"`
 
+	// APIReference locates the Kubernetes API reference used for linking
+	// types that are not listed in ExternalPackages.
+	APIReference *apiReference `json:"apiReference,omitempty"`
+
 	// APIs to process
 	Definitions []apiDefinition `json:"apis"`
 }
@@ -82,6 +93,7 @@ type apiDefinition struct {
 	Title string `json:"title"`
 
 	// Package is the import path for the API package where a type is defined.
+	// It defaults to the path of the module specified with -module-dir.
 	Package string `json:"package"`
 
 	// Path is the path for an API type/resource definition. Each package has
@@ -103,6 +115,21 @@ type apiDefinition struct {
 
 	// Resource types manually specified
 	Resources []string `json:"resources"`
+
+	// Group overrides the API group of the packages under Path, for packages
+	// which do not declare a group name.
+	Group string `json:"group,omitempty"`
+
+	// Version overrides the API version of the packages under Path, for
+	// packages whose name is not a version, e.g. "config" or "internal".
+	Version string `json:"version,omitempty"`
+
+	// SubPackages includes the packages nested under Path as well. Nested
+	// packages are combined with the ones sharing the same group and version.
+	SubPackages bool `json:"subPackages,omitempty"`
+
+	// FrontMatter customizes the Hugo front matter of the Markdown output.
+	FrontMatter *frontMatter `json:"frontMatter,omitempty"`
 }
 
 // Global vars
@@ -110,19 +137,29 @@ type apiDefinition struct {
 var typePkgMap map[string]*apiPackage
 var config generatorConfig
 var references map[string][]*apiType
+var linkIndex apiLinkIndex
+
+// diags collects the warnings and errors of the run
+var diags = diagnostics.New(os.Stderr)
 
 func init() {
 	klog.InitFlags(nil)
 
 	flag.Set("logtostderr", "true")
-	flag.Parse()
 
+	typePkgMap = make(map[string]*apiPackage)
+	references = make(map[string][]*apiType)
+}
+
+// checkFormat checks that the template directory of the output format
+// exists.
+func checkFormat() {
 	var path string
 	if *flFormat == "html" || *flFormat == "markdown" {
 		var err error
 		path, err = filepath.Abs(*flFormat)
 		if err != nil {
-			klog.Fatalf("template directory '%s' is not found: %w", path, err)
+			klog.Fatalf("template directory '%s' is not found: %v", path, err)
 		}
 	} else {
 		klog.Fatalf("unsupported format '%s' specified", *flFormat)
@@ -130,36 +167,41 @@ func init() {
 
 	fi, err := os.Stat(path)
 	if err != nil {
-		klog.Fatalf("cannot read the %s directory: %w", path, err)
+		klog.Fatalf("cannot read the %s directory: %v", path, err)
 	}
 	if !fi.IsDir() {
 		klog.Fatalf("%s path is not a directory", path)
 	}
-
-	typePkgMap = make(map[string]*apiPackage)
-	references = make(map[string][]*apiType)
 }
 
 // processAPIPath processes a path for package enumeration and processing.
-func processAPIPath(path string, includes []string, title string, mainPkg string, resources []string) ([]*apiPackage, error) {
+func processAPIPath(def *apiDefinition) ([]*apiPackage, error) {
+	if def.Path == "" {
+		return nil, errors.New("the path of the API packages is required")
+	}
+	path := def.Package + "/" + def.Path
 	klog.V(0).Infof("Parsing go packages in %s", path)
-	gopkgs, err := parseAPIPackages(path)
+	gopkgs, err := parseAPIPackages(path, def.SubPackages)
 	if err != nil {
 		return nil, err
 	}
 	if len(gopkgs) == 0 {
 		return nil, fmt.Errorf("no API packages found in %s", path)
 	}
+	overrides := make(map[*types.Package]bool)
+	for _, p := range gopkgs {
+		overrides[p] = true
+	}
 
-	for _, p := range includes {
-		extra, err := parseAPIPackages(p)
+	for _, p := range def.Includes {
+		extra, err := parseAPIPackages(p, false)
 		if err != nil {
 			return nil, err
 		}
 		gopkgs = append(gopkgs, extra...)
 	}
 
-	pkgs, err := combineAPIPackages(gopkgs, title, mainPkg, resources)
+	pkgs, err := combineAPIPackages(gopkgs, overrides, def)
 	if err != nil {
 		return nil, err
 	}
@@ -179,9 +221,10 @@ func processAPIPath(path string, includes []string, title string, mainPkg string
 	return pkgs, nil
 }
 
-// parseAPIPackages scans a given directory for packages.
-func parseAPIPackages(dir string) ([]*types.Package, error) {
-	b := parser.New()
+// parseAPIPackages scans a given directory for packages. Packages in the
+// subdirectories are returned as well when nested is true.
+func parseAPIPackages(dir string, nested bool) ([]*types.Package, error) {
+	b := newParser()
 	// the following will silently fail (turn on -v=4 to see logs)
 	if err := b.AddDirRecursive(dir); err != nil {
 		return nil, err
@@ -211,7 +254,7 @@ func parseAPIPackages(dir string) ([]*types.Package, error) {
 	var pkgs []*types.Package
 	for _, p := range pkgNames {
 		klog.V(5).Infof("Using package=%s", p)
-		if p == dir {
+		if p == dir || (nested && strings.HasPrefix(p, dir+"/")) {
 			pkgs = append(pkgs, scan[p])
 		}
 	}
@@ -219,8 +262,9 @@ func parseAPIPackages(dir string) ([]*types.Package, error) {
 }
 
 // combineAPIPackages groups the Go packages by the <apiGroup+apiVersion> they
-// offer, and combines the types in them.
-func combineAPIPackages(pkgs []*types.Package, title string, mainPkg string, resources []string) ([]*apiPackage, error) {
+// offer, and combines the types in them. The group and version overrides in
+// the API definition apply to the packages in the overrides set.
+func combineAPIPackages(pkgs []*types.Package, overrides map[*types.Package]bool, def *apiDefinition) ([]*apiPackage, error) {
 	pkgMap := make(map[string]*apiPackage)
 	re := `^v\d+((alpha|beta)\d+)?$`
 
@@ -229,8 +273,13 @@ func combineAPIPackages(pkgs []*types.Package, title string, mainPkg string, res
 		// assumes basename (i.e. "v1" in "core/v1") is apiVersion
 		version := gopkg.Name
 
-		if !regexp.MustCompile(re).MatchString(version) {
-			return nil, fmt.Errorf("cannot infer apiVersion for package %s (basename '%q' is not recognizable)", gopkg.Path, version)
+		if overrides[gopkg] && def.Group != "" {
+			group = def.Group
+		}
+		if overrides[gopkg] && def.Version != "" {
+			version = def.Version
+		} else if !regexp.MustCompile(re).MatchString(version) {
+			return nil, fmt.Errorf("cannot infer apiVersion for package %s (basename '%q' is not recognizable, consider setting 'version' in the config)", gopkg.Path, version)
 		}
 
 		typeList := make([]*apiType, 0, len(gopkg.Types))
@@ -242,7 +291,7 @@ func combineAPIPackages(pkgs []*types.Package, title string, mainPkg string, res
 		v, ok := pkgMap[id]
 		if !ok {
 			isMain := true
-			if len(mainPkg) > 0 && group != mainPkg {
+			if len(def.MainPackage) > 0 && group != def.MainPackage {
 				isMain = false
 			}
 
@@ -251,9 +300,9 @@ func combineAPIPackages(pkgs []*types.Package, title string, mainPkg string, res
 				apiVersion: version,
 				Types:      typeList,
 				GoPackages: []*types.Package{gopkg},
-				Title:      title,
+				Title:      def.Title,
 				IsMain:     isMain,
-				Resources:  resources,
+				Resources:  def.Resources,
 			}
 		} else {
 			v.Types = append(v.Types, typeList...)
@@ -273,16 +322,20 @@ func combineAPIPackages(pkgs []*types.Package, title string, mainPkg string, res
 	return out, nil
 }
 
-// render is the render procedure for templating.
-func render(w io.Writer, pkgs []*apiPackage) error {
-	var err error
-
+// renderParams returns the common parameters for rendering the packages.
+func renderParams(pkgs []*apiPackage, fm *frontMatter) map[string]interface{} {
 	gitCommit, _ := exec.Command("git", "rev-parse", "--short", "HEAD").Output()
-	params := map[string]interface{}{
-		"packages":  pkgs,
-		"config":    config,
-		"gitCommit": strings.TrimSpace(string(gitCommit)),
+	return map[string]interface{}{
+		"packages":    pkgs,
+		"config":      config,
+		"frontMatter": fm,
+		"gitCommit":   strings.TrimSpace(string(gitCommit)),
 	}
+}
+
+// render is the render procedure for templating.
+func render(w io.Writer, name string, params map[string]interface{}) error {
+	var err error
 
 	glob := filepath.Join(*flFormat, "*.tpl")
 	if *flFormat == "html" {
@@ -292,7 +345,7 @@ func render(w io.Writer, pkgs []*apiPackage) error {
 			return fmt.Errorf("parse error: %w", err)
 		}
 
-		err = tmpl.ExecuteTemplate(w, "packages", params)
+		err = tmpl.ExecuteTemplate(w, name, params)
 	} else {
 		var tmpl *texttemplate.Template
 		tmpl, err = texttemplate.New("").ParseGlob(glob)
@@ -300,7 +353,7 @@ func render(w io.Writer, pkgs []*apiPackage) error {
 			return fmt.Errorf("parse error: %w", err)
 		}
 
-		err = tmpl.ExecuteTemplate(w, "packages", params)
+		err = tmpl.ExecuteTemplate(w, name, params)
 	}
 
 	if err != nil {
@@ -311,13 +364,13 @@ func render(w io.Writer, pkgs []*apiPackage) error {
 }
 
 // writeFile creates the output file at the specified output path.
-func writeFile(pkgs []*apiPackage, outputPath string) error {
+func writeFile(pkgs []*apiPackage, fm *frontMatter, outputPath string) error {
 	dir := filepath.Dir(outputPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create dir %s: %w", dir, err)
 	}
 	var b bytes.Buffer
-	if err := render(&b, pkgs); err != nil {
+	if err := render(&b, "packages", renderParams(pkgs, fm)); err != nil {
 		return fmt.Errorf("failed to render the result: %w", err)
 	}
 	// s := regexp.MustCompile(`(?m)^\s+`).ReplaceAllString(b.String(), "")
@@ -332,6 +385,11 @@ func writeFile(pkgs []*apiPackage, outputPath string) error {
 }
 
 func main() {
+	// The flags are parsed here rather than in init so that the package
+	// can be tested.
+	flag.Parse()
+	checkFormat()
+
 	f, err := os.ReadFile(*flConfig)
 	if err != nil {
 		klog.Fatalf("Failed to open config file: %v", err)
@@ -341,6 +399,24 @@ func main() {
 		klog.Fatalf("Failed to parse config file: %v", err)
 	}
 
+	if *flModule != "" {
+		modPath, err := useModule(*flModule)
+		if err != nil {
+			klog.Fatalf("Cannot use module: %v", err)
+		}
+		for i := range config.Definitions {
+			if config.Definitions[i].Package == "" {
+				config.Definitions[i].Package = modPath
+			}
+		}
+	}
+
+	if config.APIReference != nil {
+		if err = loadAPIReference(); err != nil {
+			diags.Warningf("api-reference-disabled", diagnostics.Location{}, "links to the API reference are disabled: %v", err)
+		}
+	}
+
 	pkgInclude := []string{}
 	pkgExclude := []string{}
 	if *flInclude != "" {
@@ -350,13 +426,14 @@ func main() {
 		pkgExclude = strings.Split(*flExclude, ",")
 	}
 
+	// outputs are the files and directories written, recorded in the
+	// manifest of the output directory.
+	var outputs []string
 	for _, item := range config.Definitions {
 		if item.Skip {
 			continue
 		}
 
-		parts := []string{item.Package, item.Path}
-		apiDir := strings.Join(parts, "/")
 		// determine package to explicitly exclude, or include
 		if len(pkgExclude) > 0 && containsString(pkgExclude, item.Name) {
 			continue
@@ -364,23 +441,49 @@ func main() {
 		if len(pkgInclude) > 0 && !containsString(pkgInclude, item.Name) {
 			continue
 		}
-		pkgs, err := processAPIPath(apiDir, item.Includes, item.Title, item.MainPackage, item.Resources)
+		pkgs, err := processAPIPath(&item)
 		if err != nil {
-			klog.ErrorS(err, "cannot process API path")
+			diags.Errorf("invalid-api-path", diagnostics.Location{Definition: item.Name}, "cannot process API path %s: %v", item.Path, err)
 			continue
 		}
 
 		segments := strings.Split(item.Path, "/")
 		version := segments[len(segments)-1]
+		if item.Version != "" {
+			version = item.Version
+		}
 		fn := fmt.Sprintf("%s/%s.%s", *flPath, item.Name, version)
+		fm := pageFrontMatter(&item, pkgs)
+		if *flSplit {
+			if err = writeSplitFiles(pkgs, fm, fn); err != nil {
+				diags.Errorf("write-failed", diagnostics.Location{Definition: item.Name}, "cannot write files: %v", err)
+				continue
+			}
+			outputs = append(outputs, fn)
+			continue
+		}
 		if *flFormat == "html" {
 			fn = fn + ".html"
 		} else if *flFormat == "markdown" {
 			fn = fn + ".md"
 		}
-		if err = writeFile(pkgs, fn); err != nil {
-			klog.ErrorS(err, "cannot write file")
+		if err = writeFile(pkgs, fm, fn); err != nil {
+			diags.Errorf("write-failed", diagnostics.Location{Definition: item.Name}, "cannot write file: %v", err)
 			continue
 		}
+		outputs = append(outputs, fn)
+	}
+
+	if err := manifest.Add(*flPath, "genref", outputs...); err != nil {
+		diags.Errorf("write-failed", diagnostics.Location{}, "cannot write the manifest: %v", err)
+	}
+
+	if *flDiags != "" {
+		if err := diags.WriteReport(*flDiags); err != nil {
+			klog.Fatalf("Failed to write diagnostics: %v", err)
+		}
+	}
+	if err := diags.Err(*flStrict); err != nil {
+		klog.Exit(err)
 	}
 }
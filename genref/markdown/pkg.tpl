@@ -1,25 +1,10 @@
 {{ define "packages" -}}
 
-{{- range $idx, $val := .packages -}}
-{{/* Special handling for kubeconfig */}}
-{{- if eq .Title "kubeconfig (v1)" -}}
----
-title: {{ .Title }}
-content_type: tool-reference
-package: v1
-auto_generated: true
----
-{{- else -}}
-  {{- if and .IsMain (ne .GroupName "") -}}
----
-title: {{ .Title }}
-content_type: tool-reference
-package: {{ .DisplayName }}
-auto_generated: true
----
+{{- template "frontmatter" .frontMatter }}
+{{- range .packages -}}
+  {{- if and .IsMain (ne .GroupName "") }}
 {{ .GetComment -}}
   {{- end -}}
-{{- end -}}
 {{- end }}
 
 ## Resource Types 
@@ -51,3 +36,27 @@ auto_generated: true
   {{- end }}
 {{- end }}
 {{- end }}
+
+{{ define "frontmatter" -}}
+---
+title: {{ .Title }}
+content_type: {{ .ContentType }}
+{{- with .Package }}
+package: {{ . }}
+{{- end }}
+{{- with .Weight }}
+weight: {{ . }}
+{{- end }}
+auto_generated: {{ .IsAutoGenerated }}
+{{- with .Description }}
+description: >-
+  {{ . }}
+{{- end }}
+{{- with .Aliases }}
+aliases:
+  {{- range . }}
+  - {{ . }}
+  {{- end }}
+{{- end }}
+---
+{{- end }}
@@ -0,0 +1,24 @@
+{{ define "index" -}}
+{{- template "frontmatter" .frontMatter }}
+{{- with .main }}
+{{ .GetComment -}}
+{{- end }}
+
+## Resource Types 
+
+{{ range .kinds -}}
+- [{{ .DisplayName }}]({{ .Link }})
+{{ end }}
+
+{{- range .types }}
+{{ template "type" . }}
+{{ end }}
+{{- end }}
+
+{{ define "kind" -}}
+{{- template "frontmatter" .frontMatter }}
+
+{{- range .types }}
+{{ template "type" . }}
+{{ end }}
+{{- end }}
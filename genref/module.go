@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"k8s.io/gengo/parser"
+)
+
+var modulePathRegex = regexp.MustCompile(`(?m)^module\s+(\S+)`)
+
+// moduleDir is the absolute path of the module set with -module-dir, empty
+// to use the module genref is built from.
+var moduleDir string
+
+// useModule makes the parser resolve Go packages against the module in the
+// given directory instead of the module genref is built from, so that the
+// dependencies of that module need not be added to genref's go.mod.
+// It returns the module path declared in the go.mod file.
+func useModule(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("invalid module dir %s: %w", dir, err)
+	}
+	data, err := os.ReadFile(filepath.Join(abs, "go.mod"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	m := modulePathRegex.FindSubmatch(data)
+	if m == nil {
+		return "", fmt.Errorf("no module path found in %s", filepath.Join(abs, "go.mod"))
+	}
+
+	moduleDir = abs
+	return strings.Trim(string(m[1]), `"`), nil
+}
+
+// newParser returns a parser resolving the Go packages against the module
+// set with -module-dir, if any.
+func newParser() *parser.Builder {
+	if moduleDir == "" {
+		return parser.New()
+	}
+	// The parser copies the default build context, which runs 'go list' in
+	// the context dir to locate the packages. The default context is only
+	// changed while the parser is created.
+	dir := build.Default.Dir
+	build.Default.Dir = moduleDir
+	defer func() { build.Default.Dir = dir }()
+	return parser.New()
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// Map from type definition to the page it is rendered on when the output is
+// split into multiple files. It is empty when writing a single file.
+var typePage map[string]string
+
+// Map from the anchor of a rendered type to its page, for resolving the links
+// written in doc comments. It is empty when writing a single file.
+var anchorPage map[string]string
+
+// currentPage is the page being rendered, used for resolving local links.
+var currentPage string
+
+// indexPage returns the base name of the index page of a split output.
+func indexPage() string {
+	if *flFormat == "html" {
+		return "index"
+	}
+	// Hugo treats _index.md as the landing page of the section.
+	return "_index"
+}
+
+// pageExt returns the file extension for the output format.
+func pageExt() string {
+	if *flFormat == "html" {
+		return ".html"
+	}
+	return ".md"
+}
+
+// localLink returns the link to a local type. The link points to another
+// file when the type is rendered on a page other than the current one.
+func localLink(t *apiType) string {
+	return anchorLink(typePage[t.deref().String()], t.Anchor())
+}
+
+// anchorLink returns the link to an anchor on a page, which is the bare
+// anchor when the page is unknown or is the current one.
+func anchorLink(page, anchor string) string {
+	if page == "" || page == currentPage {
+		return "#" + anchor
+	}
+	if *flFormat == "html" {
+		return page + pageExt() + "#" + anchor
+	}
+	return fmt.Sprintf(`{{< ref "%s%s#%s" >}}`, page, pageExt(), anchor)
+}
+
+var commentLinkRegex = regexp.MustCompile(`href="#([^"]+)"`)
+
+// splitCommentLinks rewrites the links to types written in doc comments as
+// [Type](#anchor), which only work on a single page, so that they point to
+// the pages the types are rendered on.
+func splitCommentLinks(html string) string {
+	if anchorPage == nil {
+		return html
+	}
+	return commentLinkRegex.ReplaceAllStringFunc(html, func(link string) string {
+		anchor := commentLinkRegex.FindStringSubmatch(link)[1]
+		page, ok := anchorPage[anchor]
+		if !ok {
+			return link
+		}
+		return `href="` + anchorLink(page, anchor) + `"`
+	})
+}
+
+// renderedTypes returns the types that are rendered for the packages, in the
+// same way the "packages" template selects them.
+func renderedTypes(pkgs []*apiPackage) []*apiType {
+	var result []*apiType
+	for _, p := range pkgs {
+		for _, t := range p.VisibleTypes() {
+			if p.GroupName() != "" && (t.Referenced() || t.IsExported()) {
+				result = append(result, t)
+			} else if p.GroupName() == "" && t.Referenced() {
+				result = append(result, t)
+			}
+		}
+	}
+	return result
+}
+
+// mainPackage returns the main package with a group name, if any.
+func mainPackage(pkgs []*apiPackage) *apiPackage {
+	for _, p := range pkgs {
+		if p.IsMain && p.GroupName() != "" {
+			return p
+		}
+	}
+	return nil
+}
+
+// assignPages decides on which page each rendered type goes. Every top-level
+// kind gets its own page, shared with the types only it refers to, directly
+// or indirectly. Types referred to by more than one kind, or by none, are
+// rendered on the index page. The kinds are returned in page order.
+func assignPages(types []*apiType) (map[string]string, []*apiType) {
+	rendered := make(map[string]*apiType)
+	var kinds []*apiType
+	for _, t := range types {
+		rendered[t.String()] = t
+		if t.IsExported() {
+			kinds = append(kinds, t)
+		}
+	}
+
+	// Kind names are mostly unique in a package, fall back to the anchor
+	// which includes the API group and version when they are not.
+	names := make(map[string]int)
+	for _, k := range kinds {
+		names[strings.ToLower(k.Name.Name)]++
+	}
+	pageName := func(k *apiType) string {
+		name := strings.ToLower(k.Name.Name)
+		if names[name] > 1 {
+			name = strings.ToLower(k.Anchor())
+		}
+		return name
+	}
+
+	// reachedBy records the pages of the kinds from which a type is reachable.
+	reachedBy := make(map[string]map[string]bool)
+	for _, k := range kinds {
+		page := pageName(k)
+		queue := []*apiType{k}
+		seen := map[string]bool{k.String(): true}
+		for len(queue) > 0 {
+			t := queue[0]
+			queue = queue[1:]
+			for _, m := range t.Members {
+				mt := (&apiType{*m.Type}).deref()
+				id := mt.String()
+				if seen[id] {
+					continue
+				}
+				seen[id] = true
+				if _, ok := rendered[id]; !ok {
+					continue
+				}
+				if reachedBy[id] == nil {
+					reachedBy[id] = make(map[string]bool)
+				}
+				reachedBy[id][page] = true
+				queue = append(queue, rendered[id])
+			}
+		}
+	}
+
+	pages := make(map[string]string)
+	for id := range rendered {
+		pages[id] = indexPage()
+		if len(reachedBy[id]) == 1 {
+			for page := range reachedBy[id] {
+				pages[id] = page
+			}
+		}
+	}
+	for _, k := range kinds {
+		pages[k.String()] = pageName(k)
+	}
+	return pages, kinds
+}
+
+// writeSplitFiles creates one file per top-level kind and an index file in
+// the specified output directory.
+func writeSplitFiles(pkgs []*apiPackage, fm *frontMatter, outputDir string) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create dir %s: %w", outputDir, err)
+	}
+
+	types := renderedTypes(pkgs)
+	var kinds []*apiType
+	typePage, kinds = assignPages(types)
+	anchorPage = make(map[string]string)
+	for _, t := range types {
+		anchorPage[t.Anchor()] = typePage[t.String()]
+	}
+	defer func() {
+		typePage = nil
+		anchorPage = nil
+		currentPage = ""
+	}()
+
+	// Group the types by page, keeping the order in which they are rendered,
+	// except that a kind always comes first on its own page.
+	pageTypes := make(map[string][]*apiType)
+	for _, k := range kinds {
+		pageTypes[typePage[k.String()]] = []*apiType{k}
+	}
+	for _, t := range types {
+		page := typePage[t.String()]
+		if t.IsExported() && page != indexPage() {
+			continue
+		}
+		pageTypes[page] = append(pageTypes[page], t)
+	}
+
+	mainPkg := mainPackage(pkgs)
+	currentPage = indexPage()
+	params := renderParams(pkgs, fm)
+	params["main"] = mainPkg
+	params["kinds"] = kinds
+	params["types"] = pageTypes[indexPage()]
+	if err := writePage(filepath.Join(outputDir, indexPage()+pageExt()), "index", params); err != nil {
+		return err
+	}
+
+	for i, k := range kinds {
+		page := typePage[k.String()]
+		currentPage = page
+		params := renderParams(pkgs, kindFrontMatter(fm, k, i+1))
+		params["main"] = mainPkg
+		params["kind"] = k
+		params["types"] = pageTypes[page]
+		if err := writePage(filepath.Join(outputDir, page+pageExt()), "kind", params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writePage renders the named template into a file.
+func writePage(outputPath string, name string, params map[string]interface{}) error {
+	var b bytes.Buffer
+	if err := render(&b, name, params); err != nil {
+		return fmt.Errorf("failed to render %s: %w", outputPath, err)
+	}
+	if err := os.WriteFile(outputPath, b.Bytes(), 0644); err != nil {
+		return fmt.Errorf(CRED+"Failed to write output file: %w"+CEND, err)
+	}
+	klog.Infof(CGREEN+"Output written to %s"+CEND, outputPath)
+	return nil
+}
@@ -0,0 +1,4 @@
+// Package v1 is the fixture API of the genref golden test.
+//
+// +groupName=fixture.example.com
+package v1
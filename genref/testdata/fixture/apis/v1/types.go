@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Widget is a resource with a spec and a status.
+type Widget struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object metadata.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the widget.
+	Spec WidgetSpec `json:"spec"`
+
+	// Status is the observed state of the widget.
+	// +optional
+	Status *WidgetStatus `json:"status,omitempty"`
+}
+
+// WidgetSpec is the desired state of a Widget.
+type WidgetSpec struct {
+	// Replicas is the number of copies of the widget.
+	// It defaults to `1`.
+	Replicas int32 `json:"replicas"`
+
+	// Mode is the mode of the widget, one of:
+	//
+	// - `Fast`: the widget runs fast.
+	// - `Safe`: the widget runs safely.
+	Mode WidgetMode `json:"mode"`
+
+	// Timeout is the duration after which the widget gives up.
+	Timeout time.Duration `json:"timeout"`
+
+	// Labels are shared with the [Gadget](#fixture-example-com-v1-Gadget)
+	// owning the widget.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Parts of the widget.
+	Parts []Part `json:"parts,omitempty"`
+}
+
+// WidgetMode is the mode of a Widget.
+type WidgetMode string
+
+const (
+	// WidgetModeFast makes the widget run fast.
+	WidgetModeFast WidgetMode = "Fast"
+	// WidgetModeSafe makes the widget run safely.
+	WidgetModeSafe WidgetMode = "Safe"
+)
+
+// WidgetStatus is the observed state of a Widget.
+type WidgetStatus struct {
+	// Ready is true when all the parts of the widget are ready.
+	Ready bool `json:"ready"`
+
+	// LastUpdate is the time of the last update of the status.
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Gadget is a resource made of parts.
+type Gadget struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Parts of the gadget.
+	Parts []Part `json:"parts"`
+
+	// Selector selects the widgets of the gadget.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// Part is a part shared by the Widget and Gadget resources.
+type Part struct {
+	// Name of the part.
+	Name string `json:"name"`
+
+	// Weight of the part, in grams.
+	// +optional
+	Weight *int64 `json:"weight,omitempty"`
+}
+
+// ParseError is an error hidden from the reference.
+type ParseError struct {
+	// Message of the error.
+	Message string `json:"message"`
+}
@@ -0,0 +1,5 @@
+// Package v1 is the API of a module documented with -module-dir in the
+// genref golden test. It is only resolved in its own module.
+//
+// +groupName=gizmos.example.com
+package v1
@@ -0,0 +1,13 @@
+package v1
+
+// Gizmo is a resource of the module.
+type Gizmo struct {
+	// Spec is the desired state of the gizmo.
+	Spec GizmoSpec `json:"spec"`
+}
+
+// GizmoSpec is the desired state of a Gizmo.
+type GizmoSpec struct {
+	// Size of the gizmo.
+	Size int32 `json:"size"`
+}
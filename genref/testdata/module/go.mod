@@ -0,0 +1,3 @@
+module example.com/gizmos
+
+go 1.22
@@ -0,0 +1,4 @@
+// Package config is the fixture of the group and version overrides of the
+// genref golden test. It declares no group name and its name is not a
+// version, so both come from the config.
+package config
@@ -0,0 +1,11 @@
+// Package deeper is nested two levels under the overrides fixture.
+package deeper
+
+// Rate is a number of requests per second.
+type Rate struct {
+	// QPS is the number of queries per second.
+	QPS int32 `json:"qps"`
+
+	// Burst is the number of queries allowed above QPS.
+	Burst int32 `json:"burst"`
+}
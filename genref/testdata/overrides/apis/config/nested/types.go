@@ -0,0 +1,16 @@
+// Package nested is a subpackage of the overrides fixture, combined with its
+// parent package.
+package nested
+
+import (
+	"github.com/kubernetes-sigs/reference-docs/genref/testdata/overrides/apis/config/nested/deeper"
+)
+
+// Limits are the limits of the component.
+type Limits struct {
+	// MaxItems is the maximum number of items.
+	MaxItems int32 `json:"maxItems"`
+
+	// Rate limits the requests.
+	Rate deeper.Rate `json:"rate"`
+}
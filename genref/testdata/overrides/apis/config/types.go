@@ -0,0 +1,15 @@
+package config
+
+import (
+	"github.com/kubernetes-sigs/reference-docs/genref/testdata/overrides/apis/config/nested"
+)
+
+// Settings configures the fixture component.
+type Settings struct {
+	// Name of the component.
+	Name string `json:"name"`
+
+	// Limits of the component.
+	// +optional
+	Limits *nested.Limits `json:"limits,omitempty"`
+}
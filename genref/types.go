@@ -12,6 +12,7 @@ import (
 	texttemplate "text/template"
 	"unicode"
 
+	"github.com/kubernetes-sigs/reference-docs/diagnostics"
 	"github.com/yuin/goldmark"
 	highlighting "github.com/yuin/goldmark-highlighting"
 	"k8s.io/gengo/types"
@@ -221,7 +222,7 @@ func (t *apiType) APIGroup() string {
 
 	p := typePkgMap[t.String()]
 	if p == nil {
-		klog.Warningf("Cannot read apiVersion for %s from type=>pkg map", t.Name.String())
+		diags.Warningf("unknown-api-group", diagnostics.Location{Definition: t.Name.String()}, "cannot read apiVersion from type=>pkg map")
 		return "<UNKNOWN_API_GROUP>"
 	}
 
@@ -251,7 +252,7 @@ func (t *apiType) Link() string {
 	}
 
 	if t.isLocal() {
-		return "#" + t.Anchor()
+		return localLink(t)
 	}
 
 	var arrIndex = func(a []string, i int) string {
@@ -274,7 +275,7 @@ func (t *apiType) Link() string {
 		for _, v := range config.ExternalPackages {
 			r, err := regexp.Compile(v.Match)
 			if err != nil {
-				klog.Errorf("Pattern %q failed to compile: %+v", v.Match, err)
+				diags.Errorf("invalid-external-package", diagnostics.Location{Definition: id}, "pattern %q failed to compile: %v", v.Match, err)
 				return ""
 			}
 			// The type identifier is identified as a type from an "external" package
@@ -285,7 +286,7 @@ func (t *apiType) Link() string {
 				}).Parse(v.Target)
 
 				if err != nil {
-					klog.Errorf("Failed to parse the 'target': %s", v.Target)
+					diags.Errorf("invalid-external-package", diagnostics.Location{Definition: id}, "failed to parse the 'target' %s: %v", v.Target, err)
 					return ""
 				}
 
@@ -296,16 +297,20 @@ func (t *apiType) Link() string {
 					"PackageSegments": segments,
 				})
 				if err != nil {
-					klog.Errorf("Failed to execute template: %+v", err)
+					diags.Errorf("invalid-external-package", diagnostics.Location{Definition: id}, "failed to execute the 'target' %s: %v", v.Target, err)
 					return ""
 				}
 				return b.String()
 			}
 		}
 
-		// We are here if the type identifier for the type is not listed as an
-		// external one. This means we have to parse it.
-		klog.Errorf("External link source for '%s.%s' is not found.", t.Name.Package, t.Name.Name)
+		// Not listed as an external package, try the Kubernetes API reference
+		// and then fall back to the Go package documentation.
+		if link, ok := linkIndex.lookup(id); ok {
+			return link
+		}
+		klog.V(3).Infof("Linking '%s' to pkg.go.dev", id)
+		return pkgGoDevLink(t.Name.Package, t.Name.Name)
 	}
 	return ""
 }
@@ -342,7 +347,7 @@ func (t *apiType) DisplayName() string {
 		elm := apiType{*t.Elem}
 		return strings.Join([]string{"map[", stripPrefix(t.Key.Name.Name), "]", elm.DisplayName()}, "")
 	default:
-		klog.Warningf("Type '%s' has kind='%v' which is unhandled", t.Name, t.Kind)
+		diags.Warningf("unhandled-kind", diagnostics.Location{Definition: t.Name.String()}, "type has kind='%v' which is unhandled", t.Kind)
 	}
 
 	s = stripPrefix(s)
@@ -446,7 +451,7 @@ func renderComments(comments []string) template.HTML {
 			),
 		)
 		if err := md.Convert([]byte(doc), &buf); err != nil {
-			klog.Errorf("Bad doc detected: %+v", err)
+			diags.Errorf("invalid-doc", diagnostics.Location{}, "bad doc detected: %v", err)
 			res = doc
 		} else {
 			res = buf.String()
@@ -454,7 +459,7 @@ func renderComments(comments []string) template.HTML {
 	} else {
 		res = strings.ReplaceAll(doc, "\n\n", "<br/><br/>")
 	}
-	return template.HTML(res)
+	return template.HTML(splitCommentLinks(res))
 }
 
 // containsString checks if a given string is a member of the string list
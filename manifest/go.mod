@@ -0,0 +1,3 @@
+module github.com/kubernetes-sigs/reference-docs/manifest
+
+go 1.25.0
@@ -0,0 +1,260 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package manifest writes the output of the generators incrementally and
+// keeps track of the files they generated.
+//
+// A generator writes its whole output into a staging directory, then only
+// the files whose content changed are moved to the output directory, so
+// that the untouched files keep their modification time. The files of the
+// previous run which are no longer generated are removed, and the manifest
+// of the generated files, with the hash of their content, is written to the
+// output directory. Files not listed in the previous manifest, e.g. added by
+// hand, are never removed.
+package manifest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// FileName is the name of the manifest in the output directory.
+const FileName = ".manifest.json"
+
+// Manifest lists the files generated in an output directory.
+type Manifest struct {
+	// Generator is the name of the tool which generated the files.
+	Generator string `json:"generator"`
+	// Files are the SHA-256 hashes of the content of the generated files,
+	// by slash-separated path relative to the output directory.
+	Files map[string]string `json:"files"`
+}
+
+// Read reads the manifest of an output directory. It returns nil if the
+// directory has no manifest.
+func Read(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", filepath.Join(dir, FileName), err)
+	}
+	if m.Files == nil {
+		m.Files = map[string]string{}
+	}
+	return &m, nil
+}
+
+// Write writes the manifest to an output directory, unless the directory
+// already has the same manifest.
+func (m *Manifest) Write(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	return writeIfChanged(filepath.Join(dir, FileName), data)
+}
+
+// Paths returns the paths of the files of the manifest, sorted.
+func (m *Manifest) Paths() []string {
+	paths := make([]string, 0, len(m.Files))
+	for p := range m.Files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Hash returns the hash of the content of a file, as stored in a manifest.
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Stats counts the files of the output directory changed by Generate.
+type Stats struct {
+	Written   int
+	Unchanged int
+	Removed   int
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("%d files written, %d unchanged, %d removed", s.Written, s.Unchanged, s.Removed)
+}
+
+// Generate calls generate to write the output of a generator into a staging
+// directory, then updates dir with the changed files, removes the files of
+// the previous run no longer generated and writes the manifest. dir is left
+// untouched if generate fails.
+func Generate(dir, generator string, generate func(staging string) error) (Stats, error) {
+	dir = filepath.Clean(dir)
+	// The staging directory is next to dir so that files are moved, not copied.
+	parent := filepath.Dir(dir)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return Stats{}, err
+	}
+	staging, err := os.MkdirTemp(parent, "."+filepath.Base(dir)+"-")
+	if err != nil {
+		return Stats{}, err
+	}
+	defer os.RemoveAll(staging)
+
+	if err := generate(staging); err != nil {
+		return Stats{}, err
+	}
+	return update(staging, dir, generator)
+}
+
+// Add records in the manifest of dir the files written by a generator which
+// writes directly to its output directory rather than with Generate. The
+// paths are files or directories under dir. The files of the manifest which
+// no longer exist are dropped from it.
+func Add(dir, generator string, paths ...string) error {
+	m, err := Read(dir)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		m = &Manifest{Files: map[string]string{}}
+	}
+	m.Generator = generator
+	for _, rel := range m.Paths() {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(rel))); errors.Is(err, fs.ErrNotExist) {
+			delete(m.Files, rel)
+		}
+	}
+	for _, p := range paths {
+		err := filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || d.Name() == FileName {
+				return err
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			if !filepath.IsLocal(rel) {
+				return fmt.Errorf("%s is not in %s", path, dir)
+			}
+			hash, err := fileHash(path)
+			if err != nil {
+				return err
+			}
+			m.Files[filepath.ToSlash(rel)] = hash
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add generated files: %w", err)
+		}
+	}
+	return m.Write(dir)
+}
+
+// update moves the changed files from staging to dir.
+func update(staging, dir, generator string) (Stats, error) {
+	var stats Stats
+	previous, err := Read(dir)
+	if err != nil {
+		return stats, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return stats, err
+	}
+
+	current := &Manifest{Generator: generator, Files: map[string]string{}}
+	err = filepath.WalkDir(staging, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(staging, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		current.Files[filepath.ToSlash(rel)] = Hash(data)
+
+		dst := filepath.Join(dir, rel)
+		if existing, err := os.ReadFile(dst); err == nil && bytes.Equal(existing, data) {
+			stats.Unchanged++
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		if err := os.Rename(path, dst); err != nil {
+			return err
+		}
+		stats.Written++
+		return nil
+	})
+	if err != nil {
+		return stats, fmt.Errorf("failed to update %s: %w", dir, err)
+	}
+
+	if previous != nil {
+		for _, rel := range previous.Paths() {
+			if _, found := current.Files[rel]; found {
+				continue
+			}
+			file := filepath.Join(dir, filepath.FromSlash(rel))
+			if err := os.Remove(file); err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				return stats, fmt.Errorf("failed to remove stale file: %w", err)
+			}
+			stats.Removed++
+			removeEmptyDirs(dir, filepath.Dir(file))
+		}
+	}
+
+	return stats, current.Write(dir)
+}
+
+// writeIfChanged writes data to file unless file already has this content.
+func writeIfChanged(file string, data []byte) error {
+	if existing, err := os.ReadFile(file); err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+	return os.WriteFile(file, data, 0644)
+}
+
+// removeEmptyDirs removes sub and its parents up to dir, excluded, as long as
+// they are empty.
+func removeEmptyDirs(dir, sub string) {
+	for sub != dir && len(sub) > len(dir) {
+		if err := os.Remove(sub); err != nil {
+			return
+		}
+		sub = filepath.Dir(sub)
+	}
+}
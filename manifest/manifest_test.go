@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// generateFiles returns a generate function writing the files, keyed by
+// their slash-separated path.
+func generateFiles(files map[string]string) func(string) error {
+	return func(staging string) error {
+		for name, content := range files {
+			file := filepath.Join(staging, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// setOld sets the modification time of a file in the past, to detect
+// whether it is written again.
+func setOld(t *testing.T, file string) time.Time {
+	t.Helper()
+	old := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(file, old, old); err != nil {
+		t.Fatal(err)
+	}
+	return old
+}
+
+func modTime(t *testing.T, file string) time.Time {
+	t.Helper()
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info.ModTime()
+}
+
+func TestGenerate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+
+	stats, err := Generate(dir, "test", generateFiles(map[string]string{
+		"index.md":         "index",
+		"apps/_index.md":   "apps",
+		"apps/deploy.md":   "deployment v1",
+		"batch/job.md":     "job",
+		"batch/cronjob.md": "cronjob",
+	}))
+	if err != nil {
+		t.Fatalf("first Generate failed: %v", err)
+	}
+	if want := (Stats{Written: 5}); stats != want {
+		t.Errorf("first Generate: got %v, want %v", stats, want)
+	}
+
+	// A file added by hand in the output.
+	handWritten := filepath.Join(dir, "batch", "notes.md")
+	if err := os.WriteFile(handWritten, []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := setOld(t, filepath.Join(dir, "index.md"))
+
+	stats, err = Generate(dir, "test", generateFiles(map[string]string{
+		"index.md":       "index",
+		"apps/_index.md": "apps",
+		"apps/deploy.md": "deployment v2",
+	}))
+	if err != nil {
+		t.Fatalf("second Generate failed: %v", err)
+	}
+	if want := (Stats{Written: 1, Unchanged: 2, Removed: 2}); stats != want {
+		t.Errorf("second Generate: got %v, want %v", stats, want)
+	}
+
+	if got := modTime(t, filepath.Join(dir, "index.md")); !got.Equal(old) {
+		t.Errorf("unchanged index.md was written again")
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "apps", "deploy.md")); err != nil || string(data) != "deployment v2" {
+		t.Errorf("apps/deploy.md should be updated, got %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "batch", "job.md")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("stale batch/job.md should be removed, got %v", err)
+	}
+	if _, err := os.Stat(handWritten); err != nil {
+		t.Errorf("hand-written batch/notes.md should be kept: %v", err)
+	}
+
+	m, err := Read(dir)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	want := []string{"apps/_index.md", "apps/deploy.md", "index.md"}
+	if got := m.Paths(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got manifest %v, want %v", got, want)
+	}
+	if m.Generator != "test" || m.Files["index.md"] != Hash([]byte("index")) {
+		t.Errorf("unexpected manifest %+v", m)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("the staging directory should be removed, got %v", entries)
+	}
+}
+
+func TestGenerateRemovesEmptyDirs(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Generate(dir, "test", generateFiles(map[string]string{"a/b/c.md": "c", "d.md": "d"})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Generate(dir, "test", generateFiles(map[string]string{"d.md": "d"})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("empty directory a should be removed, got %v", err)
+	}
+}
+
+func TestGenerateFailure(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Generate(dir, "test", generateFiles(map[string]string{"d.md": "d"})); err != nil {
+		t.Fatal(err)
+	}
+	old := setOld(t, filepath.Join(dir, FileName))
+
+	_, err := Generate(dir, "test", func(staging string) error {
+		return errors.New("failed")
+	})
+	if err == nil {
+		t.Fatal("Generate should fail")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "d.md")); err != nil {
+		t.Errorf("output should be untouched: %v", err)
+	}
+	if got := modTime(t, filepath.Join(dir, FileName)); !got.Equal(old) {
+		t.Errorf("manifest should be untouched")
+	}
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// SyncOptions selects the files copied by Sync and how it treats the files
+// of the destination it did not create.
+type SyncOptions struct {
+	// Include lists the patterns of the files to copy, all the files if
+	// empty. A pattern matches a slash-separated path relative to the source,
+	// or one of its parent directories, with the syntax of path.Match.
+	Include []string
+	// Exclude lists the patterns of the files not to copy.
+	Exclude []string
+	// Adopt allows overwriting the files of the destination which were not
+	// created by a previous sync, e.g. copied before the destination had a
+	// manifest. They are listed in the manifest of the destination from then
+	// on.
+	Adopt bool
+	// DryRun reports the changes without making them.
+	DryRun bool
+	// Log, if not nil, receives a line for each file written or removed.
+	Log io.Writer
+}
+
+// selects tells whether the file at the slash-separated path rel is synced.
+func (o *SyncOptions) selects(rel string) bool {
+	return (len(o.Include) == 0 || matchAny(o.Include, rel)) && !matchAny(o.Exclude, rel)
+}
+
+// matchAny tells whether one of the patterns matches rel or one of its
+// parent directories.
+func matchAny(patterns []string, rel string) bool {
+	for p := rel; p != "."; p = path.Dir(p) {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, p); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Conflict is a file of the destination Sync refuses to overwrite or remove.
+type Conflict struct {
+	Path   string
+	Reason string
+}
+
+// ConflictError is returned by Sync when the destination has files it would
+// overwrite or remove although they were not created by a previous sync or
+// were modified since. The destination is left untouched.
+type ConflictError struct {
+	Dir       string
+	Conflicts []Conflict
+}
+
+func (e *ConflictError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "refusing to overwrite or remove files written or modified by hand in %s:", e.Dir)
+	for _, c := range e.Conflicts {
+		fmt.Fprintf(&b, "\n  %s: %s", c.Path, c.Reason)
+	}
+	return b.String()
+}
+
+// Sync mirrors the generated files of src into dst. The files of src are
+// listed by its manifest, or are all its files if it has none. Sync writes
+// the files which are new or changed, removes the files it copied in a
+// previous sync and which are no longer generated, and records the files
+// it copied in the manifest of dst.
+//
+// The files of dst which were not copied by a previous sync, unless their
+// content is the generated one, and the files modified since are never
+// overwritten nor removed: Sync returns a *ConflictError listing them
+// without changing dst.
+func Sync(src, dst string, opts SyncOptions) (Stats, error) {
+	var stats Stats
+	current, err := sourceFiles(src, &opts)
+	if err != nil {
+		return stats, err
+	}
+	previous, err := Read(dst)
+	if err != nil {
+		return stats, err
+	}
+	if previous == nil {
+		previous = &Manifest{Files: map[string]string{}}
+	}
+
+	var write, remove []string
+	var conflicts []Conflict
+	for _, rel := range current.Paths() {
+		existing, err := fileHash(filepath.Join(dst, filepath.FromSlash(rel)))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			write = append(write, rel)
+		case err != nil:
+			return stats, err
+		case existing == current.Files[rel]:
+			stats.Unchanged++
+		case previous.Files[rel] == existing:
+			write = append(write, rel)
+		case previous.Files[rel] != "":
+			conflicts = append(conflicts, Conflict{rel, "modified since the last sync"})
+		case opts.Adopt:
+			write = append(write, rel)
+		default:
+			conflicts = append(conflicts, Conflict{rel, "not created by a previous sync"})
+		}
+	}
+	for _, rel := range previous.Paths() {
+		if _, found := current.Files[rel]; found {
+			continue
+		}
+		existing, err := fileHash(filepath.Join(dst, filepath.FromSlash(rel)))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return stats, err
+		case existing == previous.Files[rel]:
+			remove = append(remove, rel)
+		default:
+			conflicts = append(conflicts, Conflict{rel, "no longer generated but modified since the last sync"})
+		}
+	}
+	if len(conflicts) > 0 {
+		return stats, &ConflictError{Dir: dst, Conflicts: conflicts}
+	}
+
+	stats.Written, stats.Removed = len(write), len(remove)
+	for _, rel := range write {
+		logf(opts.Log, "write %s", rel)
+	}
+	for _, rel := range remove {
+		logf(opts.Log, "remove %s", rel)
+	}
+	if opts.DryRun {
+		return stats, nil
+	}
+
+	for _, rel := range write {
+		if err := copyFile(filepath.Join(src, filepath.FromSlash(rel)), filepath.Join(dst, filepath.FromSlash(rel))); err != nil {
+			return stats, err
+		}
+	}
+	for _, rel := range remove {
+		file := filepath.Join(dst, filepath.FromSlash(rel))
+		if err := os.Remove(file); err != nil {
+			return stats, fmt.Errorf("failed to remove stale file: %w", err)
+		}
+		removeEmptyDirs(filepath.Clean(dst), filepath.Dir(file))
+	}
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return stats, err
+	}
+	return stats, current.Write(dst)
+}
+
+// sourceFiles returns the manifest of the files of src selected by opts.
+func sourceFiles(src string, opts *SyncOptions) (*Manifest, error) {
+	m, err := Read(src)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	generator := ""
+	if m != nil {
+		generator = m.Generator
+		paths = m.Paths()
+	} else {
+		err = filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || d.Name() == FileName {
+				return err
+			}
+			rel, err := filepath.Rel(src, p)
+			paths = append(paths, filepath.ToSlash(rel))
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files := map[string]string{}
+	for _, rel := range paths {
+		if !opts.selects(rel) {
+			continue
+		}
+		hash, err := fileHash(filepath.Join(src, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, fmt.Errorf("missing generated file: %w", err)
+		}
+		files[rel] = hash
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no file to sync in %s", src)
+	}
+	return &Manifest{Generator: generator, Files: files}, nil
+}
+
+func fileHash(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	return Hash(data), nil
+}
+
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0644)
+}
+
+func logf(w io.Writer, format string, args ...interface{}) {
+	if w != nil {
+		fmt.Fprintf(w, format+"\n", args...)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// sync mirrors the files generated in a directory into a destination, like
+// the website, removing only the files it copied before and refusing to
+// overwrite or remove the files written by hand.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/kubernetes-sigs/reference-docs/manifest"
+)
+
+// patterns is a flag which can be repeated.
+type patterns []string
+
+func (p *patterns) String() string { return strings.Join(*p, ",") }
+
+func (p *patterns) Set(value string) error {
+	*p = append(*p, value)
+	return nil
+}
+
+func main() {
+	var opts manifest.SyncOptions
+	flag.Var((*patterns)(&opts.Include), "include", "Pattern of the files to copy, relative to the source. Can be repeated, all the files are copied if not set.")
+	flag.Var((*patterns)(&opts.Exclude), "exclude", "Pattern of the files not to copy, relative to the source. Can be repeated.")
+	flag.BoolVar(&opts.Adopt, "adopt", false, "Overwrite the files of the destination not copied by a previous sync, e.g. the first time the destination is synced.")
+	flag.BoolVar(&opts.DryRun, "n", false, "Print the changes without making them.")
+	verbose := flag.Bool("v", false, "Print each file written or removed.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] <source dir> <destination dir>\n\n"+
+			"Copies the files generated in the source directory to the destination directory.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *verbose || opts.DryRun {
+		opts.Log = os.Stdout
+	}
+
+	src, dst := flag.Arg(0), flag.Arg(1)
+	stats, err := manifest.Sync(src, dst, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if opts.DryRun {
+		fmt.Printf("Would sync %s to %s: %s\n", src, dst, stats)
+	} else {
+		fmt.Printf("Synced %s to %s: %s\n", src, dst, stats)
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeFiles writes the files, keyed by their slash-separated path, to dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	if err := generateFiles(files)(dir); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, file string) string {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSync(t *testing.T) {
+	src, dst := filepath.Join(t.TempDir(), "src"), filepath.Join(t.TempDir(), "dst")
+	if _, err := Generate(src, "test", generateFiles(map[string]string{
+		"_index.md":      "generated index",
+		"apps/deploy.md": "deployment",
+		"batch/job.md":   "job",
+	})); err != nil {
+		t.Fatal(err)
+	}
+	// The website has a hand-written index and other pages.
+	writeFiles(t, dst, map[string]string{
+		"_index.md":        "curated index",
+		"batch/cronjob.md": "hand-written",
+	})
+
+	opts := SyncOptions{Exclude: []string{"_index.md"}}
+	stats, err := Sync(src, dst, opts)
+	if err != nil {
+		t.Fatalf("first Sync failed: %v", err)
+	}
+	if want := (Stats{Written: 2}); stats != want {
+		t.Errorf("first Sync: got %v, want %v", stats, want)
+	}
+
+	if _, err := Generate(src, "test", generateFiles(map[string]string{
+		"_index.md":      "generated index",
+		"apps/deploy.md": "deployment v2",
+	})); err != nil {
+		t.Fatal(err)
+	}
+	stats, err = Sync(src, dst, opts)
+	if err != nil {
+		t.Fatalf("second Sync failed: %v", err)
+	}
+	if want := (Stats{Written: 1, Removed: 1}); stats != want {
+		t.Errorf("second Sync: got %v, want %v", stats, want)
+	}
+
+	if got := readFile(t, filepath.Join(dst, "_index.md")); got != "curated index" {
+		t.Errorf("excluded _index.md was overwritten: %q", got)
+	}
+	if got := readFile(t, filepath.Join(dst, "apps", "deploy.md")); got != "deployment v2" {
+		t.Errorf("apps/deploy.md was not updated: %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "batch", "job.md")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("stale batch/job.md should be removed, got %v", err)
+	}
+	if got := readFile(t, filepath.Join(dst, "batch", "cronjob.md")); got != "hand-written" {
+		t.Errorf("hand-written batch/cronjob.md was changed: %q", got)
+	}
+	m, err := Read(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := m.Paths(), []string{"apps/deploy.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got manifest %v, want %v", got, want)
+	}
+}
+
+func TestSyncConflicts(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	writeFiles(t, src, map[string]string{"a.md": "a", "b.md": "b", "c.md": "c"})
+	if _, err := Sync(src, dst, SyncOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a.md is edited by hand, c.md is no longer generated but was edited,
+	// d.md is a hand-written page the generator now produces as well.
+	writeFiles(t, dst, map[string]string{"a.md": "edited a", "c.md": "edited c", "d.md": "hand-written d"})
+	if err := os.Remove(filepath.Join(src, "c.md")); err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, src, map[string]string{"a.md": "a v2", "b.md": "b v2", "d.md": "d"})
+
+	_, err := Sync(src, dst, SyncOptions{})
+	var conflicts *ConflictError
+	if !errors.As(err, &conflicts) {
+		t.Fatalf("got error %v, want a ConflictError", err)
+	}
+	var paths []string
+	for _, c := range conflicts.Conflicts {
+		paths = append(paths, c.Path)
+	}
+	if want := []string{"a.md", "d.md", "c.md"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("got conflicts %v, want %v", paths, want)
+	}
+	if got := readFile(t, filepath.Join(dst, "b.md")); got != "b" {
+		t.Errorf("the destination should be untouched on conflicts, got b.md %q", got)
+	}
+
+	// Adopting the hand-written d.md still refuses to overwrite the files
+	// edited since the last sync.
+	_, err = Sync(src, dst, SyncOptions{Adopt: true})
+	if !errors.As(err, &conflicts) || len(conflicts.Conflicts) != 2 {
+		t.Errorf("got error %v, want conflicts on a.md and c.md", err)
+	}
+}
+
+func TestSyncInclude(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	writeFiles(t, src, map[string]string{
+		"kubectl.md":                   "kubectl",
+		"kubectl_apply/_index.md":      "apply",
+		"kubectl_apply/kubectl.md":     "nested",
+		"kubeadm.md":                   "kubeadm",
+		"kubeadm_init/_index.md":       "init",
+		"flags/kube-apiserver.yaml":    "flags",
+		"man/kube-apiserver.1":         "man",
+		"kubectl_apply/.manifest.json": "{}",
+	})
+	if _, err := Sync(src, dst, SyncOptions{Include: []string{"kubectl.md", "kubectl_*"}}); err != nil {
+		t.Fatal(err)
+	}
+	m, err := Read(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"kubectl.md", "kubectl_apply/_index.md", "kubectl_apply/kubectl.md"}
+	if got := m.Paths(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSyncDryRun(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	writeFiles(t, src, map[string]string{"a.md": "a"})
+	stats, err := Sync(src, dst, SyncOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Stats{Written: 1}); stats != want {
+		t.Errorf("got %v, want %v", stats, want)
+	}
+	entries, err := os.ReadDir(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("dry run changed the destination: %v", entries)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"a.md": "a", "split/b.md": "b", "split/c.md": "c"})
+	if err := Add(dir, "test", filepath.Join(dir, "a.md")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "a.md")); err != nil {
+		t.Fatal(err)
+	}
+	if err := Add(dir, "test", filepath.Join(dir, "split")); err != nil {
+		t.Fatal(err)
+	}
+	m, err := Read(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := m.Paths(), []string{"split/b.md", "split/c.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	outside := filepath.Join(t.TempDir(), "d.md")
+	writeFiles(t, filepath.Dir(outside), map[string]string{"d.md": "d"})
+	if err := Add(dir, "test", outside); err == nil {
+		t.Error("Add should fail on files outside the directory")
+	}
+}